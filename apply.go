@@ -0,0 +1,218 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// serverManagedFields are removed from objects before they are applied, as they are set by the server.
+// Sending them, eg. a stale resourceVersion from a fetched object, would cause the apply to conflict.
+var serverManagedFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "selfLink"},
+	{"status"},
+}
+
+// kindGroups maps the supported kinds to their API group.
+var kindGroups = map[string]string{
+	PodKind:        ``,
+	NodeKind:       ``,
+	SecretKind:     ``,
+	ServiceKind:    ``,
+	DeploymentKind: DeploymentAPIGroup,
+	DaemonSetKind:  DaemonSetAPIGroup,
+	ReplicaSetKind: ReplicaSetAPIGroup,
+	IngressKind:    IngressAPIGroup,
+}
+
+// Apply creates or updates the object using server-side apply, recording the changed fields as owned by fieldManager.
+// If force is set, conflicting fields owned by other managers are taken over, otherwise a conflict error is returned.
+// The object must have its apiVersion and kind set, eg. a typed object such as *v1.ConfigMap, an *unstructured.Unstructured
+// or any of the wrapped resources. If the object namespace is not set for a namespaced kind, the namespace set on the client
+// is used or "default" if not set.
+func (c *Client) Apply(obj runtime.Object, fieldManager string, force bool) (*unstructured.Unstructured, error) {
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := c.restMapper()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := c.apply(mapper, u, fieldManager, force)
+	if meta.IsNoMatchError(err) {
+		if mapper, err = c.refreshRESTMapper(); err != nil {
+			return nil, err
+		}
+		return c.apply(mapper, u, fieldManager, force)
+	}
+	return applied, err
+}
+
+func (c *Client) apply(mapper meta.RESTMapper, u *unstructured.Unstructured, fieldManager string, force bool) (*unstructured.Unstructured, error) {
+	if fieldManager == "" {
+		return nil, fmt.Errorf("no field manager specified")
+	}
+	gvk := u.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, fmt.Errorf("object apiVersion and kind must be set")
+	}
+	if u.GetName() == "" {
+		return nil, fmt.Errorf("object name must be set")
+	}
+	u = u.DeepCopy()
+	for _, field := range serverManagedFields {
+		unstructured.RemoveNestedField(u.Object, field...)
+	}
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, err
+	}
+	ri, err := c.mappedResource(mapper, gvk, u.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	opts := c.Options[PatchOption].(*PatchAction).Get()
+	opts.FieldManager = fieldManager
+	opts.Force = &force
+	return ri.Patch(u.GetName(), types.ApplyPatchType, data, opts)
+}
+
+// Patch patches the named resource of the given kind using the patch type, eg. types.StrategicMergePatchType,
+// types.MergePatchType or types.JSONPatchType. Kind is any of the supported kinds, eg. Deployment, or Kind.group
+// for other kinds served by the cluster, eg. Certificate.cert-manager.io.
+// If the namespace is not set on the client, the "default" namespace is used for namespaced kinds.
+func (c *Client) Patch(kind, name string, pt types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	if name == "" {
+		return nil, fmt.Errorf("no %v specified", kind)
+	}
+	var gvk schema.GroupVersionKind
+	switch group, ok := kindGroups[kind]; {
+	case kind == IngressKind:
+		gvr, err := c.ingressResource()
+		if err != nil {
+			return nil, err
+		}
+		gvk = gvr.GroupVersion().WithKind(IngressKind)
+	case ok:
+		gvk = schema.GroupVersionKind{Group: group, Kind: kind}
+	default:
+		gvk = schema.ParseGroupKind(kind).WithVersion("")
+	}
+	ri, err := c.resourceFor(gvk, "")
+	if err != nil {
+		return nil, err
+	}
+	return ri.Patch(name, pt, data, c.Options[PatchOption].(*PatchAction).Get())
+}
+
+// resourceFor returns the dynamic resource interface for the kind using discovery.
+// The preferred version is used if the version is not set. Discovery is refreshed once if the kind is not found.
+func (c *Client) resourceFor(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapper, err := c.restMapper()
+	if err != nil {
+		return nil, err
+	}
+	ri, err := c.mappedResource(mapper, gvk, namespace)
+	if meta.IsNoMatchError(err) {
+		if mapper, err = c.refreshRESTMapper(); err != nil {
+			return nil, err
+		}
+		return c.mappedResource(mapper, gvk, namespace)
+	}
+	return ri, err
+}
+
+// restMapper returns a RESTMapper built from the server discovery information, cached on the client until refreshed.
+func (c *Client) restMapper() (meta.RESTMapper, error) {
+	return c.discoveryMapper(false)
+}
+
+// refreshRESTMapper rebuilds the cached RESTMapper from the current server discovery information,
+// eg. after a CustomResourceDefinition has been created.
+func (c *Client) refreshRESTMapper() (meta.RESTMapper, error) {
+	return c.discoveryMapper(true)
+}
+
+func (c *Client) discoveryMapper(refresh bool) (meta.RESTMapper, error) {
+	if c.cache != nil {
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		if c.cache.mapper != nil && !refresh {
+			return c.cache.mapper, nil
+		}
+	}
+	groups, err := restmapper.GetAPIGroupResources(c.CS.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groups)
+	if c.cache != nil {
+		c.cache.mapper = mapper
+	}
+	return mapper, nil
+}
+
+func (c *Client) mappedResource(mapper meta.RESTMapper, gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	var versions []string
+	if gvk.Version != "" {
+		versions = append(versions, gvk.Version)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), versions...)
+	if err != nil {
+		return nil, err
+	}
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return dc.Resource(mapping.Resource), nil
+	}
+	if namespace == "" {
+		namespace = c.NS
+		if namespace == "" {
+			namespace = `default`
+		}
+	}
+	return dc.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// toUnstructured converts the object to an Unstructured object.
+// Ingresses are converted back to the wire format of the API version which served them.
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	switch o := obj.(type) {
+	case nil:
+		return nil, fmt.Errorf("no object specified")
+	case *unstructured.Unstructured:
+		return o.DeepCopy(), nil
+	case *Ingress:
+		if o.IngressObject == nil {
+			return nil, fmt.Errorf("no ingress specified")
+		}
+		return ingressToUnstructured(o.IngressObject, o.APIVersion)
+	case *IngressObject:
+		return ingressToUnstructured(o, o.APIVersion)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var u unstructured.Unstructured
+	if err := json.Unmarshal(data, &u.Object); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
@@ -0,0 +1,142 @@
+package ak8s
+
+import (
+	"crypto/x509"
+	"sort"
+	"time"
+
+	"k8s.io/api/core/v1"
+)
+
+// CertificateReport contains the results of scanning a single TLS Secret.
+// Hosts are the Ingress TLS hosts referencing the Secret and MismatchedHosts are those not covered by the certificate.
+type CertificateReport struct {
+	Namespace       string    `json:"namespace"`
+	Secret          string    `json:"secret"`
+	Subject         string    `json:"subject,omitempty"`
+	Issuer          string    `json:"issuer,omitempty"`
+	DNSNames        []string  `json:"dnsNames,omitempty"`
+	NotBefore       time.Time `json:"notBefore,omitempty"`
+	NotAfter        time.Time `json:"notAfter,omitempty"`
+	ChainLength     int       `json:"chainLength"`
+	Expired         bool      `json:"expired"`
+	Expiring        bool      `json:"expiring"`
+	ChainExpired    bool      `json:"chainExpired"`
+	Ingresses       []string  `json:"ingresses,omitempty"`
+	Hosts           []string  `json:"hosts,omitempty"`
+	MismatchedHosts []string  `json:"mismatchedHosts,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// HasIssues returns true if the certificate is expired, expiring, has mismatched hosts or could not be read.
+func (r *CertificateReport) HasIssues() bool {
+	return r.Expired || r.Expiring || r.ChainExpired || len(r.MismatchedHosts) > 0 || r.Error != ""
+}
+
+// ScanCertificates scans all TLS Secrets in the current namespace set on the client, or all namespaces if not set,
+// reporting certificates expiring within the given duration.
+func (c *Client) ScanCertificates(expiringWithin time.Duration) ([]CertificateReport, error) {
+	secrets, err := c.GetAllSecrets()
+	if err != nil {
+		return nil, err
+	}
+	ingresses, err := c.GetAllIngress()
+	if err != nil {
+		return nil, err
+	}
+	return ScanCertificates(secrets, ingresses, expiringWithin), nil
+}
+
+// ScanCertificates parses the certificate chain of every kubernetes.io/tls Secret in secrets, correlates each to the
+// Ingress TLS hosts referencing it and reports expired, expiring and hostname mismatched certificates.
+// Ingress TLS entries referencing a missing Secret are also reported. Reports are sorted by expiration.
+func ScanCertificates(secrets *SecretCollection, ingresses *IngressCollection, expiringWithin time.Duration) []CertificateReport {
+	now := time.Now()
+	reports := make(map[string]*CertificateReport)
+	leaves := make(map[string]*x509.Certificate)
+	var keys []string
+	if secrets != nil && secrets.SecretList != nil {
+		for i := range secrets.Items {
+			if secrets.Items[i].Type != v1.SecretTypeTLS {
+				continue
+			}
+			secret := Secret{SecretAPIVersion, SecretKind, &secrets.Items[i]}
+			report := CertificateReport{
+				Namespace: secret.Namespace,
+				Secret:    secret.Name,
+			}
+			key := secret.Namespace + `/` + secret.Name
+			certs, err := secret.TLSCertificates()
+			if err != nil {
+				report.Error = err.Error()
+			} else {
+				leaf := certs[0]
+				leaves[key] = leaf
+				report.Subject = leaf.Subject.String()
+				report.Issuer = leaf.Issuer.String()
+				report.DNSNames = leaf.DNSNames
+				report.NotBefore = leaf.NotBefore
+				report.NotAfter = leaf.NotAfter
+				report.ChainLength = len(certs)
+				report.Expired = now.After(leaf.NotAfter)
+				report.Expiring = !report.Expired && now.Add(expiringWithin).After(leaf.NotAfter)
+				for _, cert := range certs[1:] {
+					if now.After(cert.NotAfter) {
+						report.ChainExpired = true
+					}
+				}
+			}
+			reports[key] = &report
+			keys = append(keys, key)
+		}
+	}
+	if ingresses != nil && ingresses.IngressObjectList != nil {
+		for _, ing := range ingresses.Items {
+			for _, tls := range ing.Spec.TLS {
+				if tls.SecretName == "" {
+					continue
+				}
+				key := ing.Namespace + `/` + tls.SecretName
+				report, ok := reports[key]
+				if !ok {
+					report = &CertificateReport{
+						Namespace: ing.Namespace,
+						Secret:    tls.SecretName,
+						Error:     `tls secret not found`,
+					}
+					reports[key] = report
+					keys = append(keys, key)
+				}
+				report.Ingresses = appendUnique(report.Ingresses, ing.Name)
+				for _, host := range tls.Hosts {
+					report.Hosts = appendUnique(report.Hosts, host)
+				}
+			}
+		}
+	}
+	var list []CertificateReport
+	for _, key := range keys {
+		report := reports[key]
+		if leaf, ok := leaves[key]; ok {
+			for _, host := range report.Hosts {
+				if leaf.VerifyHostname(host) != nil {
+					report.MismatchedHosts = append(report.MismatchedHosts, host)
+				}
+			}
+		}
+		list = append(list, *report)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].NotAfter.Before(list[j].NotAfter)
+	})
+	return list
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, l := range list {
+		if l == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
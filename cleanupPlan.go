@@ -0,0 +1,301 @@
+package ak8s
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Cleanup Reason Constants:
+const (
+	CleanupOldReplicaSet         = `replicaset scaled to zero from an old deployment revision`
+	CleanupOrphanedPod           = `pod has no controller owner`
+	CleanupUnselectedService     = `service selector matches no pods`
+	CleanupUnreferencedSecret    = `secret possibly unreferenced, not used by any workload or ingress`
+	CleanupIngressMissingService = `ingress backend services not found`
+	CleanupIngressBrokenRoutes   = `ingress has routes to services not found`
+)
+
+// helmReleaseSecretType is the Secret type used by Helm 3 to store releases.
+const helmReleaseSecretType = `helm.sh/release.v1`
+
+// cleanupSkippedNamespaces are never included in a CleanupPlan, as their resources are managed by cluster components.
+var cleanupSkippedNamespaces = map[string]bool{
+	`kube-system`: true,
+}
+
+// CleanupItem is a resource found to be orphaned or stale, along with why.
+// ReportOnly is set for items that may still be in use and are not removed by Delete, eg. possibly unreferenced Secrets
+// or Services whose Pods are scaled to zero.
+type CleanupItem struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Reason     string   `json:"reason"`
+	Details    []string `json:"details,omitempty"`
+	ReportOnly bool     `json:"reportOnly,omitempty"`
+}
+
+// CleanupPlan contains the orphaned and stale resources found across collections.
+type CleanupPlan struct {
+	Items []CleanupItem `json:"items"`
+}
+
+// ByKind returns the items in the plan of the given kind.
+func (p *CleanupPlan) ByKind(kind string) []CleanupItem {
+	var items []CleanupItem
+	for _, item := range p.Items {
+		if item.Kind == kind {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetCleanupPlan returns a CleanupPlan built from all Deployments, DaemonSets, ReplicaSets, Pods, Services, Secrets and Ingresses
+// for the current namespace set on the client or across all namespaces if not set.
+func (c *Client) GetCleanupPlan() (*CleanupPlan, error) {
+	deps, err := c.GetAllDeployments()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	dss, err := c.GetAllDaemonSets()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	rss, err := c.GetAllReplicaSets()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	pods, err := c.GetAllPods()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	services, err := c.GetAllServices()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	secrets, err := c.GetAllSecrets()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	ingresses, err := c.GetAllIngress()
+	if err != nil {
+		return &CleanupPlan{}, err
+	}
+	return NewCleanupPlan(deps, dss, rss, pods, services, secrets, ingresses), nil
+}
+
+// NewCleanupPlan returns a CleanupPlan built from the given collections, any of which may be nil.
+// The collections should cover the same namespaces, as references are only resolved within them. Resources in kube-system are skipped.
+// Orphaned Pods, Services selecting no Pods and Secrets not referenced by the workloads are reported only and are never deleted
+// by the plan, as they may still be in use, eg. by a workload scaled to zero, a StatefulSet, CronJob or controller.
+// Service account token, bootstrap token, Helm release and controller owned Secrets, along with Secrets referenced by
+// Ingress TLS, are not reported. Ingresses are only deleted by the plan when none of their routes resolve to a service.
+func NewCleanupPlan(deps *DeployomentCollection, dss *DaemonSetCollection, rss *ReplicaSetCollection, pods *PodCollection, services *ServiceCollection, secrets *SecretCollection, ingresses *IngressCollection) *CleanupPlan {
+	var plan CleanupPlan
+	if rss != nil && rss.ReplicaSetList != nil {
+		plan.Items = append(plan.Items, oldReplicaSets(rss)...)
+	}
+	if pods != nil && pods.PodList != nil {
+		for _, pod := range pods.Items {
+			if metav1.GetControllerOf(&pod) != nil || pod.Annotations[mirrorPodAnnotation] != "" || cleanupSkippedNamespaces[pod.Namespace] {
+				continue
+			}
+			plan.Items = append(plan.Items, CleanupItem{
+				Kind:       PodKind,
+				Namespace:  pod.Namespace,
+				Name:       pod.Name,
+				Reason:     CleanupOrphanedPod,
+				Details:    []string{fmt.Sprintf("phase %v", pod.Status.Phase)},
+				ReportOnly: true,
+			})
+		}
+	}
+	if services != nil && services.ServiceList != nil && pods != nil && pods.PodList != nil {
+		for _, svc := range services.Items {
+			if len(svc.Spec.Selector) < 1 || svc.Spec.Type == v1.ServiceTypeExternalName || cleanupSkippedNamespaces[svc.Namespace] {
+				continue
+			}
+			selector := labels.SelectorFromSet(svc.Spec.Selector)
+			var matched bool
+			for _, pod := range pods.Items {
+				if pod.Namespace == svc.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				plan.Items = append(plan.Items, CleanupItem{
+					Kind:       ServiceKind,
+					Namespace:  svc.Namespace,
+					Name:       svc.Name,
+					Reason:     CleanupUnselectedService,
+					Details:    []string{fmt.Sprintf("selector %v", selector)},
+					ReportOnly: true,
+				})
+			}
+		}
+	}
+	if secrets != nil && secrets.SecretList != nil {
+		idx := NewReferenceIndex(deps, dss, rss, pods)
+		tlsSecrets := make(map[string]bool)
+		if ingresses != nil && ingresses.IngressObjectList != nil {
+			for _, ing := range ingresses.Items {
+				for _, tls := range ing.Spec.TLS {
+					tlsSecrets[ing.Namespace+`/`+tls.SecretName] = true
+				}
+			}
+		}
+		for _, secret := range secrets.Items {
+			switch {
+			case secret.Type == v1.SecretTypeServiceAccountToken, secret.Type == v1.SecretTypeBootstrapToken, secret.Type == helmReleaseSecretType:
+				continue
+			case cleanupSkippedNamespaces[secret.Namespace], metav1.GetControllerOf(&secret) != nil:
+				continue
+			case tlsSecrets[secret.Namespace+`/`+secret.Name]:
+				continue
+			case len(idx.SecretReferences(secret.Namespace, secret.Name)) > 0:
+				continue
+			}
+			plan.Items = append(plan.Items, CleanupItem{
+				Kind:       SecretKind,
+				Namespace:  secret.Namespace,
+				Name:       secret.Name,
+				Reason:     CleanupUnreferencedSecret,
+				Details:    []string{fmt.Sprintf("type %v", secret.Type)},
+				ReportOnly: true,
+			})
+		}
+	}
+	if ingresses != nil && ingresses.IngressObjectList != nil && services != nil && services.ServiceList != nil {
+		details := make(map[string][]string)
+		routes := make(map[string]int)
+		var order []CleanupItem
+		for _, route := range ingresses.RoutingTable(services).Routes {
+			if cleanupSkippedNamespaces[route.Namespace] {
+				continue
+			}
+			key := route.Namespace + `/` + route.Ingress
+			routes[key]++
+			if len(route.Issues) < 1 || route.Issues[0] != RouteIssueServiceNotFound {
+				continue
+			}
+			if _, ok := details[key]; !ok {
+				order = append(order, CleanupItem{
+					Kind:      IngressKind,
+					Namespace: route.Namespace,
+					Name:      route.Ingress,
+				})
+			}
+			host := route.Host
+			if host == "" {
+				host = `*`
+			}
+			details[key] = append(details[key], fmt.Sprintf("%v%v -> %v", host, route.Path, route.Service))
+		}
+		for _, item := range order {
+			key := item.Namespace + `/` + item.Name
+			item.Details = details[key]
+			item.Reason = CleanupIngressMissingService
+			if len(details[key]) < routes[key] {
+				item.Reason = CleanupIngressBrokenRoutes
+				item.ReportOnly = true
+			}
+			plan.Items = append(plan.Items, item)
+		}
+	}
+	return &plan
+}
+
+// oldReplicaSets returns the ReplicaSets scaled to zero that belong to an old revision of their owning Deployment.
+func oldReplicaSets(rss *ReplicaSetCollection) []CleanupItem {
+	latest := make(map[string]int64)
+	for i := range rss.Items {
+		if ref := metav1.GetControllerOf(&rss.Items[i]); ref != nil && ref.Kind == DeploymentKind {
+			if rev := replicaSetRevision(&rss.Items[i]); rev > latest[string(ref.UID)] {
+				latest[string(ref.UID)] = rev
+			}
+		}
+	}
+	var items []CleanupItem
+	for i := range rss.Items {
+		rs := &rss.Items[i]
+		ref := metav1.GetControllerOf(rs)
+		if ref == nil || ref.Kind != DeploymentKind || cleanupSkippedNamespaces[rs.Namespace] {
+			continue
+		}
+		if rs.Spec.Replicas == nil || *rs.Spec.Replicas != 0 || rs.Status.Replicas != 0 {
+			continue
+		}
+		rev := replicaSetRevision(rs)
+		if rev >= latest[string(ref.UID)] {
+			continue
+		}
+		items = append(items, CleanupItem{
+			Kind:      ReplicaSetKind,
+			Namespace: rs.Namespace,
+			Name:      rs.Name,
+			Reason:    CleanupOldReplicaSet,
+			Details:   []string{fmt.Sprintf("deployment %v revision %d of %d", ref.Name, rev, latest[string(ref.UID)])},
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+// Delete deletes every resource in the plan not marked ReportOnly using the delete options set on the client and returns the items deleted.
+// Resources in kube-system are never deleted. Deleting old ReplicaSets removes those revisions from the Deployment rollout history.
+// Errors are collected per item and returned together.
+func (p *CleanupPlan) Delete(c *Client) ([]CleanupItem, error) {
+	var deleted []CleanupItem
+	var errd string
+	for _, item := range p.Items {
+		if item.ReportOnly || cleanupSkippedNamespaces[item.Namespace] {
+			continue
+		}
+		if err := c.deleteCleanupItem(item); err != nil {
+			errd += (fmt.Sprintf("%v %v/%v: %v", item.Kind, item.Namespace, item.Name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		deleted = append(deleted, item)
+	}
+	if errd != "" {
+		return deleted, fmt.Errorf("%v", errd)
+	}
+	return deleted, nil
+}
+
+func (c *Client) deleteCleanupItem(item CleanupItem) error {
+	opts := c.Options[DeleteOption].(*DeleteAction).Get()
+	switch item.Kind {
+	case ReplicaSetKind:
+		return c.CS.AppsV1().ReplicaSets(item.Namespace).Delete(item.Name, &opts)
+	case PodKind:
+		return c.CS.CoreV1().Pods(item.Namespace).Delete(item.Name, &opts)
+	case ServiceKind:
+		return c.CS.CoreV1().Services(item.Namespace).Delete(item.Name, &opts)
+	case SecretKind:
+		return c.CS.CoreV1().Secrets(item.Namespace).Delete(item.Name, &opts)
+	case IngressKind:
+		gvr, err := c.ingressResource()
+		if err != nil {
+			return err
+		}
+		dc, err := c.dynamicClient()
+		if err != nil {
+			return err
+		}
+		return dc.Resource(gvr).Namespace(item.Namespace).Delete(item.Name, &opts)
+	default:
+		return fmt.Errorf("unsupported kind %v", item.Kind)
+	}
+}
@@ -0,0 +1,134 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+func TestCleanupPlanDelete(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/apis/networking.k8s.io/v1" {
+			json.NewEncoder(w).Encode(metav1.APIResourceList{
+				GroupVersion: "networking.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: ingressResource, Namespaced: true, Kind: IngressKind}},
+			})
+			return
+		}
+		if r.Method != http.MethodDelete {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(metav1.Status{Status: metav1.StatusSuccess})
+	}))
+	defer srv.Close()
+	client, err := newClientForConfig(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := func(ns, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: ns, Name: name}
+	}
+	owned := func(ns, name, rev string) appsv1.ReplicaSet {
+		controller := true
+		zero := int32(0)
+		rs := appsv1.ReplicaSet{ObjectMeta: meta(ns, name)}
+		rs.Annotations = map[string]string{revisionAnnotation: rev}
+		rs.OwnerReferences = []metav1.OwnerReference{{Kind: DeploymentKind, Name: "web", UID: types.UID(ns + "/web"), Controller: &controller}}
+		rs.Spec.Replicas = &zero
+		return rs
+	}
+	ingress := func(name string, services ...string) IngressObject {
+		var paths []HTTPIngressPath
+		for _, svc := range services {
+			paths = append(paths, HTTPIngressPath{Path: "/" + svc, Backend: IngressBackend{Service: &IngressServiceBackend{Name: svc, Port: ServiceBackendPort{Number: 80}}}})
+		}
+		return IngressObject{ObjectMeta: meta("app", name), Spec: IngressSpec{Rules: []IngressRule{{Host: "example.com", HTTP: &HTTPIngressRuleValue{Paths: paths}}}}}
+	}
+
+	rss := &ReplicaSetCollection{ReplicaSetList: &appsv1.ReplicaSetList{Items: []appsv1.ReplicaSet{
+		owned("app", "web-1", "1"),
+		owned("app", "web-2", "2"),
+		owned("kube-system", "web-1", "1"),
+		owned("kube-system", "web-2", "2"),
+	}}}
+	pods := &PodCollection{PodList: &v1.PodList{Items: []v1.Pod{
+		{ObjectMeta: meta("app", "orphan")},
+		{ObjectMeta: meta("kube-system", "orphan")},
+	}}}
+	services := &ServiceCollection{ServiceList: &v1.ServiceList{Items: []v1.Service{
+		{ObjectMeta: meta("app", "api"), Spec: v1.ServiceSpec{Selector: map[string]string{"app": "api"}, Ports: []v1.ServicePort{{Port: 80}}}},
+	}}}
+	secrets := &SecretCollection{SecretList: &v1.SecretList{Items: []v1.Secret{
+		{ObjectMeta: meta("app", "unused")},
+		{ObjectMeta: meta("kube-system", "unused")},
+	}}}
+	ingresses := &IngressCollection{IngressObjectList: &IngressObjectList{Items: []IngressObject{
+		ingress("broken", "gone"),
+		ingress("partial", "api", "gone"),
+	}}}
+
+	plan := NewCleanupPlan(nil, nil, rss, pods, services, secrets, ingresses)
+	reportOnly := make(map[string]bool)
+	for _, item := range plan.Items {
+		if item.Namespace == "kube-system" {
+			t.Errorf("unexpected kube-system item in plan: %+v", item)
+		}
+		reportOnly[item.Kind+" "+item.Namespace+"/"+item.Name] = item.ReportOnly
+	}
+	expected := map[string]bool{
+		"ReplicaSet app/web-1": false,
+		"Pod app/orphan":       true,
+		"Service app/api":      true,
+		"Secret app/unused":    true,
+		"Ingress app/broken":   false,
+		"Ingress app/partial":  true,
+	}
+	for key, want := range expected {
+		got, ok := reportOnly[key]
+		switch {
+		case !ok:
+			t.Errorf("expected %v in plan", key)
+		case got != want:
+			t.Errorf("%v: expected ReportOnly %v, got %v", key, want, got)
+		}
+	}
+	if len(reportOnly) != len(expected) {
+		t.Errorf("expected %d items, got %v", len(expected), reportOnly)
+	}
+
+	plan.Items = append(plan.Items, CleanupItem{Kind: PodKind, Namespace: "kube-system", Name: "manual"})
+	items, err := plan.Delete(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(deleted)
+	want := []string{
+		"/apis/apps/v1/namespaces/app/replicasets/web-1",
+		"/apis/networking.k8s.io/v1/namespaces/app/ingresses/broken",
+	}
+	if len(items) != len(want) || len(deleted) != len(want) {
+		t.Fatalf("expected %v deleted, got %v", want, deleted)
+	}
+	for i := range want {
+		if deleted[i] != want[i] {
+			t.Errorf("expected %v deleted, got %v", want[i], deleted[i])
+		}
+	}
+}
@@ -1,60 +1,77 @@
 package ak8s
 
 import (
+	"fmt"
 	"log"
+	"sync"
 
-	"k8s.io/client-go/kubernetes" 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 // Client intereacts with Kubernetes.
 type Client struct {
 	Options ActionsMap
 	CS      *kubernetes.Clientset
+	Config  *rest.Config
 	NS      string
+
+	cache *clientCache
+}
+
+// clientCache holds the clients created on demand for a Client, shared with its copies, eg. from WithDryRun.
+type clientCache struct {
+	mu      sync.Mutex
+	dynamic dynamic.Interface
+	ingress *schema.GroupVersionResource
+	mapper  meta.RESTMapper
 }
 
 // NewClient returns a new Client using your kube config or inCluster if running within a pod.
 func NewClient(inCluster bool) (*Client, error) {
-	var client Client
 	if inCluster {
-		cs, err := CreateICClientSet()
+		config, err := rest.InClusterConfig()
 		if err != nil {
-			return &client, err
+			return &Client{}, err
 		}
-		client.CS = cs
-		client.Options = makeActionMap()
-		return &client, nil
+		return newClientForConfig(config)
 	}
-	cs, err := CreateClientSet()
+	config, err := GetKubeConfig()
 	if err != nil {
-		return &client, err
+		return &Client{}, err
 	}
-	client.CS = cs
-	client.Options = makeActionMap()
-	return &client, nil
+	return newClientForConfig(config)
 }
 
 // NewClientFromConfig returns a new Client using the given configPath.
 func NewClientFromConfig(configPath string) (*Client, error) {
-	var client Client
-	cs, err := CreateClientSetFromConfig(configPath)
+	config, err := clientcmd.BuildConfigFromFlags("", configPath)
 	if err != nil {
-		return &client, err
+		return &Client{}, err
 	}
-	client.CS = cs
-	client.Options = makeActionMap()
-	return &client, nil
+	return newClientForConfig(config)
 }
 
 // NewUserClient returns a new Client using username/password values.
 func NewUserClient(host, username, password string, insecure bool) (*Client, error) {
+	return newClientForConfig(GetUserConfig(host, username, password, insecure))
+}
+
+func newClientForConfig(config *rest.Config) (*Client, error) {
 	var client Client
-	cs, err := CreateUserClientSet(host, username, password, insecure)
+	cs, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return &client, err
 	}
 	client.CS = cs
+	client.Config = config
 	client.Options = makeActionMap()
+	client.cache = &clientCache{}
 	return &client, nil
 }
 
@@ -70,3 +87,41 @@ func (c *Client) GetAPIGroups() []string {
 	}
 	return groups
 }
+
+// dynamicClient returns a dynamic client using the rest config set on the client, created once and reused.
+func (c *Client) dynamicClient() (dynamic.Interface, error) {
+	if c.Config == nil {
+		return nil, fmt.Errorf("no rest config found for client")
+	}
+	if c.cache == nil {
+		return dynamic.NewForConfig(c.Config)
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	if c.cache.dynamic == nil {
+		dc, err := dynamic.NewForConfig(c.Config)
+		if err != nil {
+			return nil, err
+		}
+		c.cache.dynamic = dc
+	}
+	return c.cache.dynamic, nil
+}
+
+// serverHasResource returns true if the server supports the given resource for the groupVersion, eg. networking.k8s.io/v1 ingresses.
+// A groupVersion not served by the server returns false, any other discovery error is returned.
+func (c *Client) serverHasResource(groupVersion, resource string) (bool, error) {
+	list, err := c.CS.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	switch {
+	case errors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	for _, r := range list.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -12,6 +12,9 @@ const (
 	ListOption   ActionOption = 0
 	GetOption    ActionOption = 1
 	DeleteOption ActionOption = 2
+	CreateOption ActionOption = 3
+	UpdateOption ActionOption = 4
+	PatchOption  ActionOption = 5
 )
 
 // ActionsMap maps ActionOptions to K8sActions.
@@ -67,8 +70,116 @@ func (a *DeleteAction) Get() v1.DeleteOptions {
 	return a.DeleteOptions
 }
 
+// SetDryRun sets or clears server-side dry-run for Delete type Actions.
+func (a *DeleteAction) SetDryRun(dryRun bool) {
+	a.DeleteOptions.DryRun = dryRunValue(dryRun)
+}
+
+// CreateAction contains Options for performing Create type Actions.
+type CreateAction struct {
+	CreateOptions v1.CreateOptions
+}
+
+// GetType implements K8sAction.
+func (a *CreateAction) GetType() ActionOption {
+	return CreateOption
+}
+
+// Get returns the v1 Option.
+func (a *CreateAction) Get() v1.CreateOptions {
+	return a.CreateOptions
+}
+
+// SetDryRun sets or clears server-side dry-run for Create type Actions.
+func (a *CreateAction) SetDryRun(dryRun bool) {
+	a.CreateOptions.DryRun = dryRunValue(dryRun)
+}
+
+// UpdateAction contains Options for performing Update type Actions.
+type UpdateAction struct {
+	UpdateOptions v1.UpdateOptions
+}
+
+// GetType implements K8sAction.
+func (a *UpdateAction) GetType() ActionOption {
+	return UpdateOption
+}
+
+// Get returns the v1 Option.
+func (a *UpdateAction) Get() v1.UpdateOptions {
+	return a.UpdateOptions
+}
+
+// SetDryRun sets or clears server-side dry-run for Update type Actions.
+func (a *UpdateAction) SetDryRun(dryRun bool) {
+	a.UpdateOptions.DryRun = dryRunValue(dryRun)
+}
+
+// PatchAction contains Options for performing Patch type Actions.
+type PatchAction struct {
+	PatchOptions v1.PatchOptions
+}
+
+// GetType implements K8sAction.
+func (a *PatchAction) GetType() ActionOption {
+	return PatchOption
+}
+
+// Get returns the v1 Option.
+func (a *PatchAction) Get() v1.PatchOptions {
+	return a.PatchOptions
+}
+
+// SetDryRun sets or clears server-side dry-run for Patch type Actions.
+func (a *PatchAction) SetDryRun(dryRun bool) {
+	a.PatchOptions.DryRun = dryRunValue(dryRun)
+}
+
+// DryRunAction is a K8sAction supporting server-side dry-run.
+type DryRunAction interface {
+	K8sAction
+	SetDryRun(bool)
+}
+
+func dryRunValue(dryRun bool) []string {
+	if dryRun {
+		return []string{v1.DryRunAll}
+	}
+	return nil
+}
+
+// clone returns a copy of the ActionsMap whose Actions may be changed without affecting the original.
+func (m ActionsMap) clone() ActionsMap {
+	actionsMap := make(map[ActionOption]K8sAction, len(m))
+	for option, action := range m {
+		switch a := action.(type) {
+		case *ListAction:
+			c := *a
+			actionsMap[option] = &c
+		case *GetAction:
+			c := *a
+			actionsMap[option] = &c
+		case *DeleteAction:
+			c := *a
+			actionsMap[option] = &c
+		case *CreateAction:
+			c := *a
+			actionsMap[option] = &c
+		case *UpdateAction:
+			c := *a
+			actionsMap[option] = &c
+		case *PatchAction:
+			c := *a
+			actionsMap[option] = &c
+		default:
+			actionsMap[option] = action
+		}
+	}
+	return actionsMap
+}
+
 func makeActionMap() ActionsMap {
-	actionsMap := make(map[ActionOption]K8sAction, 2)
+	actionsMap := make(map[ActionOption]K8sAction, 6)
 	actionsMap[ListOption] = &ListAction{
 		ListOptions: v1.ListOptions{},
 	}
@@ -78,5 +189,14 @@ func makeActionMap() ActionsMap {
 	actionsMap[DeleteOption] = &DeleteAction{
 		DeleteOptions: v1.DeleteOptions{},
 	}
+	actionsMap[CreateOption] = &CreateAction{
+		CreateOptions: v1.CreateOptions{},
+	}
+	actionsMap[UpdateOption] = &UpdateAction{
+		UpdateOptions: v1.UpdateOptions{},
+	}
+	actionsMap[PatchOption] = &PatchAction{
+		PatchOptions: v1.PatchOptions{},
+	}
 	return actionsMap
 }
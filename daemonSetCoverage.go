@@ -0,0 +1,266 @@
+package ak8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// DaemonSet Coverage Constants:
+const (
+	CoverageNodeSelectorMismatch = `node selector does not match`
+	CoverageAffinityMismatch     = `required node affinity does not match`
+	CoverageTaintNotTolerated    = `taint not tolerated`
+	CoverageNoPod                = `no daemon pod on node`
+	CoveragePodPending           = `pod pending`
+	CoveragePodNotReady          = `pod not ready`
+)
+
+// nodeNameField is the field used by the DaemonSet controller to pin daemon Pods to a Node with node affinity.
+const nodeNameField = `metadata.name`
+
+// daemonSetDefaultTolerations are added to every daemon Pod by the DaemonSet controller.
+var daemonSetDefaultTolerations = []v1.Toleration{
+	{Key: `node.kubernetes.io/not-ready`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: `node.kubernetes.io/unreachable`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: `node.kubernetes.io/disk-pressure`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: `node.kubernetes.io/memory-pressure`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: `node.kubernetes.io/pid-pressure`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: `node.kubernetes.io/unschedulable`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+}
+
+// hostNetworkTolerations are added to daemon Pods using the host network by the DaemonSet controller.
+var hostNetworkTolerations = []v1.Toleration{
+	{Key: `node.kubernetes.io/network-unavailable`, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+}
+
+// NodeCoverage describes whether a Node should and does run a daemon Pod.
+// Eligible is set if the DaemonSet should schedule a Pod to the Node and Reasons explains any gap,
+// eg. a taint not tolerated, a node selector mismatch or a pending Pod.
+type NodeCoverage struct {
+	Node     string   `json:"node"`
+	Eligible bool     `json:"eligible"`
+	Pods     []string `json:"pods,omitempty"`
+	Running  bool     `json:"running"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+// DaemonSetCoverage compares the Nodes a DaemonSet should run on with the Nodes running its Pods.
+// Running counts the eligible Nodes with a ready daemon Pod.
+type DaemonSetCoverage struct {
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Eligible  int            `json:"eligible"`
+	Running   int            `json:"running"`
+	Nodes     []NodeCoverage `json:"nodes"`
+}
+
+// Missing returns the eligible Nodes without a ready daemon Pod.
+func (d *DaemonSetCoverage) Missing() []NodeCoverage {
+	var missing []NodeCoverage
+	for _, node := range d.Nodes {
+		if node.Eligible && !node.Running {
+			missing = append(missing, node)
+		}
+	}
+	return missing
+}
+
+// Excluded returns the Nodes the DaemonSet does not schedule to, along with why.
+func (d *DaemonSetCoverage) Excluded() []NodeCoverage {
+	var excluded []NodeCoverage
+	for _, node := range d.Nodes {
+		if !node.Eligible {
+			excluded = append(excluded, node)
+		}
+	}
+	return excluded
+}
+
+// Coverage compares the scheduling constraints of the DaemonSet, its nodeSelector, required node affinity and tolerations,
+// with the taints and labels of each Node in nodes and the daemon Pods found in pods.
+func (r *DaemonSet) Coverage(nodes *NodeCollection, pods *PodCollection) *DaemonSetCoverage {
+	if r.DaemonSet == nil {
+		return &DaemonSetCoverage{}
+	}
+	coverage := DaemonSetCoverage{
+		Namespace: r.Namespace,
+		Name:      r.Name,
+	}
+	nodePods := make(map[string][]*v1.Pod)
+	if pods != nil && pods.PodList != nil {
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if ref := metav1.GetControllerOf(pod); ref == nil || ref.UID != r.UID {
+				continue
+			}
+			node := daemonPodNode(pod)
+			nodePods[node] = append(nodePods[node], pod)
+		}
+	}
+	spec := &r.Spec.Template.Spec
+	tolerations := append(append([]v1.Toleration{}, spec.Tolerations...), daemonSetDefaultTolerations...)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, hostNetworkTolerations...)
+	}
+	if nodes == nil || nodes.NodeList == nil {
+		return &coverage
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		nc := NodeCoverage{
+			Node:    node.Name,
+			Reasons: daemonSetNodeMismatches(spec, tolerations, node),
+		}
+		nc.Eligible = len(nc.Reasons) < 1
+		var podReasons []string
+		for _, pod := range nodePods[node.Name] {
+			p := Pod{PodAPIVersion, PodKind, pod}
+			nc.Pods = append(nc.Pods, pod.Name)
+			switch {
+			case p.IsReady():
+				nc.Running = true
+			case pod.Status.Phase == v1.PodPending:
+				podReasons = appendUnique(podReasons, CoveragePodPending)
+			default:
+				podReasons = appendUnique(podReasons, CoveragePodNotReady)
+			}
+		}
+		if !nc.Running {
+			nc.Reasons = append(nc.Reasons, podReasons...)
+			if nc.Eligible && len(nc.Pods) < 1 {
+				nc.Reasons = append(nc.Reasons, CoverageNoPod)
+			}
+		}
+		if nc.Eligible {
+			coverage.Eligible++
+			if nc.Running {
+				coverage.Running++
+			}
+		}
+		coverage.Nodes = append(coverage.Nodes, nc)
+	}
+	sort.Slice(coverage.Nodes, func(i, j int) bool {
+		return coverage.Nodes[i].Node < coverage.Nodes[j].Node
+	})
+	return &coverage
+}
+
+// Coverage returns the DaemonSetCoverage of each DaemonSet in the collection.
+func (c *DaemonSetCollection) Coverage(nodes *NodeCollection, pods *PodCollection) []DaemonSetCoverage {
+	var coverage []DaemonSetCoverage
+	for i := 0; i < len(c.Items); i++ {
+		ds := DaemonSet{DaemonSetAPIVersion, DaemonSetKind, &c.Items[i]}
+		coverage = append(coverage, *ds.Coverage(nodes, pods))
+	}
+	return coverage
+}
+
+// daemonPodNode returns the Node a daemon Pod runs on, or for pending Pods the Node it is pinned to by node affinity.
+func daemonPodNode(pod *v1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == nodeNameField && field.Operator == v1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// daemonSetNodeMismatches returns why a daemon Pod with the given PodSpec and tolerations cannot be scheduled to the Node.
+func daemonSetNodeMismatches(spec *v1.PodSpec, tolerations []v1.Toleration, node *v1.Node) []string {
+	var reasons []string
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		reasons = append(reasons, fmt.Sprintf("%v: %v", CoverageNodeSelectorMismatch, labels.SelectorFromSet(spec.NodeSelector)))
+	}
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil && spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !nodeSelectorMatches(spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, node) {
+			reasons = append(reasons, CoverageAffinityMismatch)
+		}
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		var tolerated bool
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			reasons = append(reasons, fmt.Sprintf("%v: %v", CoverageTaintNotTolerated, taint.ToString()))
+		}
+	}
+	return reasons
+}
+
+// nodeSelectorMatches returns true if the Node matches any of the NodeSelector terms.
+func nodeSelectorMatches(ns *v1.NodeSelector, node *v1.Node) bool {
+	for _, term := range ns.NodeSelectorTerms {
+		if len(term.MatchExpressions) < 1 && len(term.MatchFields) < 1 {
+			continue
+		}
+		if requirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) &&
+			requirementsMatch(term.MatchFields, labels.Set{nodeNameField: node.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+// requirementsMatch returns true if the set matches all of the NodeSelector requirements.
+func requirementsMatch(reqs []v1.NodeSelectorRequirement, set labels.Set) bool {
+	for _, req := range reqs {
+		var op selection.Operator
+		switch req.Operator {
+		case v1.NodeSelectorOpIn:
+			op = selection.In
+		case v1.NodeSelectorOpNotIn:
+			op = selection.NotIn
+		case v1.NodeSelectorOpExists:
+			op = selection.Exists
+		case v1.NodeSelectorOpDoesNotExist:
+			op = selection.DoesNotExist
+		case v1.NodeSelectorOpGt:
+			op = selection.GreaterThan
+		case v1.NodeSelectorOpLt:
+			op = selection.LessThan
+		default:
+			return false
+		}
+		r, err := labels.NewRequirement(req.Key, op, req.Values)
+		if err != nil || !r.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns a summary of the coverage, eg. 3/4 eligible nodes running.
+func (d *DaemonSetCoverage) String() string {
+	var missing []string
+	for _, node := range d.Missing() {
+		missing = append(missing, node.Node)
+	}
+	s := fmt.Sprintf("%v/%v: %d/%d eligible nodes running", d.Namespace, d.Name, d.Running, d.Eligible)
+	if len(missing) > 0 {
+		s += `, missing ` + strings.Join(missing, `,`)
+	}
+	return s
+}
@@ -0,0 +1,165 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Rollout Constants:
+const (
+	restartedAtAnnotation = `kubectl.kubernetes.io/restartedAt`
+	revisionAnnotation    = `deployment.kubernetes.io/revision`
+	changeCauseAnnotation = `kubernetes.io/change-cause`
+	lastAppliedAnnotation = `kubectl.kubernetes.io/last-applied-configuration`
+)
+
+// rollbackSkippedAnnotations are not copied from a ReplicaSet to its Deployment on rollback, matching kubectl.
+var rollbackSkippedAnnotations = map[string]bool{
+	lastAppliedAnnotation:                       true,
+	revisionAnnotation:                          true,
+	`deployment.kubernetes.io/revision-history`: true,
+	`deployment.kubernetes.io/desired-replicas`: true,
+	`deployment.kubernetes.io/max-replicas`:     true,
+	`deprecated.deployment.rollback.to`:         true,
+}
+
+// Restart triggers a rolling restart of the Deployment by patching the restartedAt Pod template annotation.
+func (r *Deployment) Restart(c *Client) error {
+	if r.Deployment == nil {
+		return fmt.Errorf("no deployment specified")
+	}
+	if r.Spec.Paused {
+		return fmt.Errorf("deployment %v is paused, resume it before restarting", r.Name)
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+	return r.patch(c, types.StrategicMergePatchType, patch)
+}
+
+// Pause pauses the rollout of the Deployment. Changes to a paused Deployment are not rolled out until it is resumed.
+func (r *Deployment) Pause(c *Client) error {
+	return r.setPaused(c, true)
+}
+
+// Resume resumes the rollout of a paused Deployment.
+func (r *Deployment) Resume(c *Client) error {
+	return r.setPaused(c, false)
+}
+
+func (r *Deployment) setPaused(c *Client, paused bool) error {
+	if r.Deployment == nil {
+		return fmt.Errorf("no deployment specified")
+	}
+	if r.Spec.Paused == paused {
+		return nil
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"paused":%t}}`, paused))
+	return r.patch(c, types.StrategicMergePatchType, patch)
+}
+
+// Undo rolls the Deployment back to the Pod template of the given revision, or the previous revision if toRevision is 0.
+// The ReplicaSets owned by the Deployment are located by their revision annotation and the matching template
+// and change annotations are copied back to the Deployment. The revision rolled back to is returned.
+func (r *Deployment) Undo(c *Client, toRevision int64) (int64, error) {
+	if r.Deployment == nil {
+		return 0, fmt.Errorf("no deployment specified")
+	}
+	if r.Spec.Paused {
+		return 0, fmt.Errorf("deployment %v is paused, resume it before rolling back", r.Name)
+	}
+	rsList, err := deploymentReplicaSets(c, r.Deployment)
+	if err != nil {
+		return 0, err
+	}
+	rs, revision, err := rollbackReplicaSet(rsList, toRevision)
+	if err != nil {
+		return 0, err
+	}
+	template := rs.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	if equality.Semantic.DeepEqual(template, &r.Spec.Template) {
+		return revision, fmt.Errorf("deployment %v is already at the template of revision %d", r.Name, revision)
+	}
+	annotations := make(map[string]string)
+	for k, v := range r.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range rs.Annotations {
+		if !rollbackSkippedAnnotations[k] {
+			annotations[k] = v
+		}
+	}
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "replace", "path": "/spec/template", "value": template},
+		{"op": "replace", "path": "/metadata/annotations", "value": annotations},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return revision, r.patch(c, types.JSONPatchType, patch)
+}
+
+func (r *Deployment) patch(c *Client, pt types.PatchType, data []byte) error {
+	dep := &appsv1.Deployment{}
+	if err := c.patchObject(c.CS.AppsV1().RESTClient(), `deployments`, r.Namespace, r.Name, pt, data, dep); err != nil {
+		return err
+	}
+	dep.APIVersion, dep.Kind = r.APIVersion, r.Kind
+	r.Deployment = dep
+	return nil
+}
+
+// rolloutRestartDeployment triggers a rolling restart of the Deployment by patching its Pod template annotations.
+func rolloutRestartDeployment(c *Client, dep *appsv1.Deployment) error {
+	d := Deployment{DeploymentAPIVersion, DeploymentKind, dep.DeepCopy()}
+	return d.Restart(c)
+}
+
+// deploymentReplicaSets returns the ReplicaSets controlled by the Deployment, sorted by revision.
+func deploymentReplicaSets(c *Client, dep *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	list, err := c.CS.AppsV1().ReplicaSets(dep.Namespace).List(c.Options[ListOption].(*ListAction).Get())
+	if err != nil {
+		return nil, err
+	}
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == dep.UID {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return replicaSetRevision(&owned[i]) < replicaSetRevision(&owned[j])
+	})
+	return owned, nil
+}
+
+// rollbackReplicaSet returns the ReplicaSet for the given revision from ReplicaSets sorted by revision,
+// or the ReplicaSet of the previous revision if toRevision is 0.
+func rollbackReplicaSet(rsList []appsv1.ReplicaSet, toRevision int64) (*appsv1.ReplicaSet, int64, error) {
+	if toRevision == 0 {
+		if len(rsList) < 2 {
+			return nil, 0, fmt.Errorf("no rollout history found")
+		}
+		rs := &rsList[len(rsList)-2]
+		return rs, replicaSetRevision(rs), nil
+	}
+	for i := range rsList {
+		if replicaSetRevision(&rsList[i]) == toRevision {
+			return &rsList[i], toRevision, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("unable to find revision %d", toRevision)
+}
+
+// replicaSetRevision returns the revision annotation of the ReplicaSet, or 0 if not set.
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
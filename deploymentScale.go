@@ -0,0 +1,344 @@
+package ak8s
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
+)
+
+// Scale Constants:
+const (
+	defaultWaitTimeout = 5 * time.Minute
+)
+
+// WaitOptions contains the Options used when waiting for a Deployment or ReplicaSet to become ready.
+// Timeout defaults to 5 minutes if not set.
+// Progress, if set, is called with the current replica counts each time the status changes.
+type WaitOptions struct {
+	Timeout  time.Duration
+	Progress func(ScaleProgress)
+}
+
+// ScaleProgress describes the replica counts of a Deployment or ReplicaSet while waiting for it to become ready.
+// Replicas is the desired replica count, Current the total number of Pods, including Pods from older revisions.
+type ScaleProgress struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Replicas  int32
+	Current   int32
+	Updated   int32
+	Ready     int32
+	Available int32
+
+	generation         int64
+	observedGeneration int64
+	resourceVersion    string
+}
+
+// Done returns true once the latest spec has been observed and the updated, available and current
+// replica counts all match the desired replica count.
+func (p ScaleProgress) Done() bool {
+	return p.observedGeneration >= p.generation &&
+		p.Updated == p.Replicas &&
+		p.Available == p.Replicas &&
+		p.Current == p.Replicas
+}
+
+// String returns a summary of the replica counts.
+func (p ScaleProgress) String() string {
+	return fmt.Sprintf("%v/%v: %d/%d updated, %d/%d ready, %d/%d available", p.Namespace, p.Name, p.Updated, p.Replicas, p.Ready, p.Replicas, p.Available, p.Replicas)
+}
+
+// ScaleDeployment sets the replica count of the given deployment name through the scale subresource.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) ScaleDeployment(name string, replicas int32) (*Deployment, error) {
+	dep, err := c.GetDeployment(name)
+	if err != nil {
+		return dep, err
+	}
+	return dep, dep.Scale(c, replicas)
+}
+
+// ScaleReplicaSet sets the replica count of the given replicaset name through the scale subresource.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) ScaleReplicaSet(name string, replicas int32) (*ReplicaSet, error) {
+	rs, err := c.GetReplicaSet(name)
+	if err != nil {
+		return rs, err
+	}
+	return rs, rs.Scale(c, replicas)
+}
+
+// Scale sets the replica count of the Deployment through the scale subresource, retrying on update conflicts.
+func (r *Deployment) Scale(c *Client, replicas int32) error {
+	if r.Deployment == nil {
+		return fmt.Errorf("no deployment specified")
+	}
+	deployments := c.CS.AppsV1().Deployments(r.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := deployments.GetScale(r.Name, c.Options[GetOption].(*GetAction).Get())
+		if err != nil {
+			return err
+		}
+		if scale.Spec.Replicas == replicas {
+			return nil
+		}
+		scale.Spec.Replicas = replicas
+		return c.updateObject(c.CS.AppsV1().RESTClient(), `deployments`, r.Namespace, r.Name, scale, &autoscalingv1.Scale{}, `scale`)
+	})
+	if err != nil {
+		return err
+	}
+	if c.DryRun() {
+		r.Deployment = r.Deployment.DeepCopy()
+		r.Spec.Replicas = &replicas
+		return nil
+	}
+	dep, err := deployments.Get(r.Name, c.Options[GetOption].(*GetAction).Get())
+	if err != nil {
+		return err
+	}
+	dep.APIVersion, dep.Kind = r.APIVersion, r.Kind
+	r.Deployment = dep
+	return nil
+}
+
+// WaitForReady watches the Deployment until its updated and available replicas match the desired replica count
+// and no Pods from older revisions remain, or until the timeout is reached.
+func (r *Deployment) WaitForReady(c *Client, opts *WaitOptions) error {
+	if r.Deployment == nil {
+		return fmt.Errorf("no deployment specified")
+	}
+	deployments := c.CS.AppsV1().Deployments(r.Namespace)
+	get := func() (ScaleProgress, error) {
+		dep, err := deployments.Get(r.Name, c.Options[GetOption].(*GetAction).Get())
+		if err != nil {
+			return ScaleProgress{}, err
+		}
+		return deploymentProgress(dep), nil
+	}
+	progress := func(obj runtime.Object) (ScaleProgress, bool) {
+		dep, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return ScaleProgress{}, false
+		}
+		return deploymentProgress(dep), true
+	}
+	return waitForScale(r.Namespace, r.Name, opts, get, deployments.Watch, progress)
+}
+
+// Scale sets the replica count of the ReplicaSet through the scale subresource, retrying on update conflicts.
+// An error is returned for ReplicaSets managed by a Deployment, as the Deployment would scale them back, scale the Deployment instead.
+func (r *ReplicaSet) Scale(c *Client, replicas int32) error {
+	if r.ReplicaSet == nil {
+		return fmt.Errorf("no replicaset specified")
+	}
+	if ref := metav1.GetControllerOf(r.ReplicaSet); ref != nil && ref.Kind == DeploymentKind {
+		return fmt.Errorf("replicaset %v is managed by deployment %v, scale the deployment instead", r.Name, ref.Name)
+	}
+	replicaSets := c.CS.AppsV1().ReplicaSets(r.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := replicaSets.GetScale(r.Name, c.Options[GetOption].(*GetAction).Get())
+		if err != nil {
+			return err
+		}
+		if scale.Spec.Replicas == replicas {
+			return nil
+		}
+		scale.Spec.Replicas = replicas
+		return c.updateObject(c.CS.AppsV1().RESTClient(), `replicasets`, r.Namespace, r.Name, scale, &autoscalingv1.Scale{}, `scale`)
+	})
+	if err != nil {
+		return err
+	}
+	if c.DryRun() {
+		r.ReplicaSet = r.ReplicaSet.DeepCopy()
+		r.Spec.Replicas = &replicas
+		return nil
+	}
+	rs, err := replicaSets.Get(r.Name, c.Options[GetOption].(*GetAction).Get())
+	if err != nil {
+		return err
+	}
+	rs.APIVersion, rs.Kind = r.APIVersion, r.Kind
+	r.ReplicaSet = rs
+	return nil
+}
+
+// WaitForReady watches the ReplicaSet until its ready and available replicas match the desired replica count,
+// or until the timeout is reached.
+func (r *ReplicaSet) WaitForReady(c *Client, opts *WaitOptions) error {
+	if r.ReplicaSet == nil {
+		return fmt.Errorf("no replicaset specified")
+	}
+	replicaSets := c.CS.AppsV1().ReplicaSets(r.Namespace)
+	get := func() (ScaleProgress, error) {
+		rs, err := replicaSets.Get(r.Name, c.Options[GetOption].(*GetAction).Get())
+		if err != nil {
+			return ScaleProgress{}, err
+		}
+		return replicaSetProgress(rs), nil
+	}
+	progress := func(obj runtime.Object) (ScaleProgress, bool) {
+		rs, ok := obj.(*appsv1.ReplicaSet)
+		if !ok {
+			return ScaleProgress{}, false
+		}
+		return replicaSetProgress(rs), true
+	}
+	return waitForScale(r.Namespace, r.Name, opts, get, replicaSets.Watch, progress)
+}
+
+// Scale sets the replica count of all Deployments in the collection, eg. the result of a Search.
+// If wait is not nil, the Deployments are then waited on concurrently until ready, unless dry-run is set on the client.
+// Errors are collected per Deployment and returned together.
+func (c *DeployomentCollection) Scale(client *Client, replicas int32, wait *WaitOptions) error {
+	var errd string
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	addErr := func(name string, err error) {
+		mu.Lock()
+		errd += (fmt.Sprintf("%v: %v", name, err) + fmt.Sprintf("\n"))
+		mu.Unlock()
+	}
+	var scaled []*Deployment
+	for i := 0; i < len(c.Items); i++ {
+		dep := &Deployment{DeploymentAPIVersion, DeploymentKind, c.Items[i].DeepCopy()}
+		if err := dep.Scale(client, replicas); err != nil {
+			addErr(dep.Name, err)
+			continue
+		}
+		c.Items[i] = *dep.Deployment
+		scaled = append(scaled, dep)
+	}
+	if wait != nil && !client.DryRun() {
+		for _, dep := range scaled {
+			wg.Add(1)
+			go func(dep *Deployment) {
+				defer wg.Done()
+				if err := dep.WaitForReady(client, wait); err != nil {
+					addErr(dep.Name, err)
+				}
+			}(dep)
+		}
+		wg.Wait()
+	}
+	if errd != "" {
+		return fmt.Errorf("%v", errd)
+	}
+	return nil
+}
+
+func deploymentProgress(dep *appsv1.Deployment) ScaleProgress {
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	return ScaleProgress{
+		Kind:               DeploymentKind,
+		Namespace:          dep.Namespace,
+		Name:               dep.Name,
+		Replicas:           replicas,
+		Current:            dep.Status.Replicas,
+		Updated:            dep.Status.UpdatedReplicas,
+		Ready:              dep.Status.ReadyReplicas,
+		Available:          dep.Status.AvailableReplicas,
+		generation:         dep.Generation,
+		observedGeneration: dep.Status.ObservedGeneration,
+		resourceVersion:    dep.ResourceVersion,
+	}
+}
+
+func replicaSetProgress(rs *appsv1.ReplicaSet) ScaleProgress {
+	replicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+	return ScaleProgress{
+		Kind:               ReplicaSetKind,
+		Namespace:          rs.Namespace,
+		Name:               rs.Name,
+		Replicas:           replicas,
+		Current:            rs.Status.Replicas,
+		Updated:            rs.Status.Replicas,
+		Ready:              rs.Status.ReadyReplicas,
+		Available:          rs.Status.AvailableReplicas,
+		generation:         rs.Generation,
+		observedGeneration: rs.Status.ObservedGeneration,
+		resourceVersion:    rs.ResourceVersion,
+	}
+}
+
+// waitForScale watches a single named resource, reporting progress on each change until it is Done or the timeout is reached.
+// The watch is re-established from a fresh get if it is closed or returns an error, eg. an expired resource version.
+func waitForScale(namespace, name string, opts *WaitOptions, get func() (ScaleProgress, error), watchFn func(metav1.ListOptions) (watch.Interface, error), progress func(runtime.Object) (ScaleProgress, bool)) error {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	report := func(p ScaleProgress) bool {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+		return p.Done()
+	}
+	for {
+		p, err := get()
+		if err != nil {
+			return err
+		}
+		if report(p) {
+			return nil
+		}
+		w, err := watchFn(metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector(`metadata.name`, name).String(),
+			ResourceVersion: p.resourceVersion,
+		})
+		if err != nil {
+			return err
+		}
+	events:
+		for {
+			select {
+			case <-deadline.C:
+				w.Stop()
+				return fmt.Errorf("timed out waiting for %v", p)
+			case event, ok := <-w.ResultChan():
+				if !ok || event.Type == watch.Error {
+					break events
+				}
+				if event.Type == watch.Deleted {
+					w.Stop()
+					return fmt.Errorf("%v/%v was deleted", namespace, name)
+				}
+				if next, ok := progress(event.Object); ok {
+					p = next
+					if report(p) {
+						w.Stop()
+						return nil
+					}
+				}
+			}
+		}
+		w.Stop()
+		select {
+		case <-deadline.C:
+			return fmt.Errorf("timed out waiting for %v", p)
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package ak8s
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+// SetDryRun sets or clears server-side dry-run (DryRun: All) for all mutating operations performed by the client.
+// While set, create, update, patch, eviction and delete requests are validated and admitted by the server
+// but not persisted, and the returned objects describe the result had they been.
+// Operations run through exec, eg. Exec, CopyToPod and CopyFromPod, cannot be previewed and return an error while set.
+func (c *Client) SetDryRun(dryRun bool) {
+	for _, action := range c.Options {
+		if a, ok := action.(DryRunAction); ok {
+			a.SetDryRun(dryRun)
+		}
+	}
+}
+
+// DryRun returns true if server-side dry-run is set on the client.
+func (c *Client) DryRun() bool {
+	return len(c.Options[DeleteOption].(*DeleteAction).Get().DryRun) > 0
+}
+
+// WithDryRun returns a copy of the client with server-side dry-run set, for previewing a single call,
+// eg. client.WithDryRun().DeletePods("web-0"). The original client is not changed.
+func (c *Client) WithDryRun() *Client {
+	client := *c
+	client.Options = c.Options.clone()
+	client.SetDryRun(true)
+	return &client
+}
+
+// createObject creates obj using the REST client for its API group, honoring the create options set on the client.
+func (c *Client) createObject(rc rest.Interface, resource, namespace string, obj, into runtime.Object) error {
+	opts := c.Options[CreateOption].(*CreateAction).Get()
+	return rc.Post().
+		Namespace(namespace).
+		Resource(resource).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(obj).
+		Do().
+		Into(into)
+}
+
+// updateObject updates obj using the REST client for its API group, honoring the update options set on the client.
+func (c *Client) updateObject(rc rest.Interface, resource, namespace, name string, obj, into runtime.Object, subresources ...string) error {
+	opts := c.Options[UpdateOption].(*UpdateAction).Get()
+	return rc.Put().
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(obj).
+		Do().
+		Into(into)
+}
+
+// patchObject patches the named resource using the REST client for its API group, honoring the patch options set on the client.
+func (c *Client) patchObject(rc rest.Interface, resource, namespace, name string, pt types.PatchType, data []byte, into runtime.Object, subresources ...string) error {
+	opts := c.Options[PatchOption].(*PatchAction).Get()
+	return rc.Patch(pt).
+		Namespace(namespace).
+		Resource(resource).
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do().
+		Into(into)
+}
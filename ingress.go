@@ -1,59 +1,77 @@
 package ak8s
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 
-	"k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // Ingresses Constants:
 const (
-	IngressAPIGroup   = `extensions`
-	IngressAPIVersion = `v1beta1`
+	IngressAPIGroup   = `networking.k8s.io`
+	IngressAPIVersion = `v1`
 	IngressListKind   = `List`
 	IngressKind       = `Ingress`
+	ingressResource   = `ingresses`
 )
 
+// ingressGroupVersions are the Ingress API versions in order of preference.
+var ingressGroupVersions = []schema.GroupVersion{
+	{Group: IngressAPIGroup, Version: IngressAPIVersion},
+	{Group: IngressAPIGroup, Version: `v1beta1`},
+	{Group: `extensions`, Version: `v1beta1`},
+}
+
 // IngressCollection Contains a Collection of Ingresses.
 type IngressCollection struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
-	*v1beta1.IngressList
+	*IngressObjectList
 }
 
-// Ingress contains a v1.Ingresses resource.
+// Ingress contains a normalized Ingress resource.
+// APIVersion reports the API version which served the Ingress.
 type Ingress struct {
 	APIVersion string `json:"apiVersion"`
 	Kind       string `json:"kind"`
-	*v1beta1.Ingress
+	*IngressObject
 }
 
 // GetAllIngress returns All Ingresses for the current namespace set on the client or all Ingresses across all namespaces if not set.
+// Ingresses are retrieved using networking.k8s.io/v1 if served, otherwise falling back to networking.k8s.io/v1beta1 or extensions/v1beta1.
 func (c *Client) GetAllIngress() (*IngressCollection, error) {
-	list, err := c.CS.ExtensionsV1beta1().Ingresses(c.NS).List(c.Options[ListOption].(*ListAction).Get())
+	gvr, err := c.ingressResource()
 	if err != nil {
 		return &IngressCollection{}, err
 	}
-	list.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   IngressAPIGroup,
-		Version: IngressAPIVersion,
-		Kind:    IngressListKind,
-	})
-	for i := 0; i < len(list.Items); i++ {
-		list.Items[i].SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   IngressAPIGroup,
-			Version: IngressAPIVersion,
-			Kind:    IngressKind,
-		})
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return &IngressCollection{}, err
 	}
+	ul, err := dc.Resource(gvr).Namespace(c.NS).List(c.Options[ListOption].(*ListAction).Get())
+	if err != nil {
+		return &IngressCollection{}, err
+	}
+	var list IngressObjectList
+	list.ResourceVersion = ul.GetResourceVersion()
+	list.Continue = ul.GetContinue()
+	for i := range ul.Items {
+		ing, err := ingressFromUnstructured(&ul.Items[i], gvr.GroupVersion())
+		if err != nil {
+			return &IngressCollection{}, err
+		}
+		list.Items = append(list.Items, *ing)
+	}
+	list.SetGroupVersionKind(gvr.GroupVersion().WithKind(IngressListKind))
 	return &IngressCollection{
 		list.APIVersion,
 		list.Kind,
-		list,
+		&list,
 	}, nil
 }
 
@@ -67,23 +85,31 @@ func (c *Client) GetIngresses(names ...string) (*IngressCollection, error) {
 	if ns == "" {
 		ns = `default`
 	}
-	var Ingresses []v1beta1.Ingress
-	var list v1beta1.IngressList
+	gvr, err := c.ingressResource()
+	if err != nil {
+		return &IngressCollection{}, err
+	}
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return &IngressCollection{}, err
+	}
+	var Ingresses []IngressObject
+	var list IngressObjectList
 	var errd string
 	var Err error
 	for _, name := range names {
-		p, err := c.CS.ExtensionsV1beta1().Ingresses(ns).Get(name, c.Options[GetOption].(*GetAction).Get())
+		u, err := dc.Resource(gvr).Namespace(ns).Get(name, c.Options[GetOption].(*GetAction).Get())
+		if err == nil {
+			var p *IngressObject
+			if p, err = ingressFromUnstructured(u, gvr.GroupVersion()); err == nil {
+				Ingresses = append(Ingresses, *p)
+			}
+		}
 		if err != nil {
 			errd += (err.Error() + fmt.Sprintf("\n"))
-		} else {
-			Ingresses = append(Ingresses, *p)
 		}
 	}
-	list.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   IngressAPIGroup,
-		Version: IngressAPIVersion,
-		Kind:    IngressListKind,
-	})
+	list.SetGroupVersionKind(gvr.GroupVersion().WithKind(IngressListKind))
 	switch {
 	case len(Ingresses) < 1 && errd != "":
 		return &IngressCollection{}, fmt.Errorf("%v", errd)
@@ -93,13 +119,6 @@ func (c *Client) GetIngresses(names ...string) (*IngressCollection, error) {
 		Err = fmt.Errorf("%v", errd)
 	}
 	list.Items = Ingresses
-	for i := 0; i < len(list.Items); i++ {
-		list.Items[i].SetGroupVersionKind(schema.GroupVersionKind{
-			Group:   IngressAPIGroup,
-			Version: IngressAPIVersion,
-			Kind:    IngressKind,
-		})
-	}
 	return &IngressCollection{
 		list.APIVersion,
 		list.Kind,
@@ -113,15 +132,22 @@ func (c *Client) GetIngress(name string) (*Ingress, error) {
 	if ns == "" {
 		ns = `default`
 	}
-	p, err := c.CS.ExtensionsV1beta1().Ingresses(ns).Get(name, c.Options[GetOption].(*GetAction).Get())
+	gvr, err := c.ingressResource()
+	if err != nil {
+		return &Ingress{}, err
+	}
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return &Ingress{}, err
+	}
+	u, err := dc.Resource(gvr).Namespace(ns).Get(name, c.Options[GetOption].(*GetAction).Get())
+	if err != nil {
+		return &Ingress{}, err
+	}
+	p, err := ingressFromUnstructured(u, gvr.GroupVersion())
 	if err != nil {
 		return &Ingress{}, err
 	}
-	p.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   IngressAPIGroup,
-		Version: IngressAPIVersion,
-		Kind:    IngressKind,
-	})
 	return &Ingress{
 		p.APIVersion,
 		p.Kind,
@@ -129,6 +155,118 @@ func (c *Client) GetIngress(name string) (*Ingress, error) {
 	}, nil
 }
 
+// IngressGroupVersion returns the preferred Ingress API group version served by the cluster, eg. networking.k8s.io/v1.
+func (c *Client) IngressGroupVersion() (string, error) {
+	gvr, err := c.ingressResource()
+	if err != nil {
+		return "", err
+	}
+	return gvr.GroupVersion().String(), nil
+}
+
+// ingressResource returns the preferred Ingress resource served by the cluster using discovery.
+// The resource is resolved once and reused for later calls on the client.
+func (c *Client) ingressResource() (schema.GroupVersionResource, error) {
+	if c.cache != nil {
+		c.cache.mu.Lock()
+		defer c.cache.mu.Unlock()
+		if c.cache.ingress != nil {
+			return *c.cache.ingress, nil
+		}
+	}
+	for _, gv := range ingressGroupVersions {
+		ok, err := c.serverHasResource(gv.String(), ingressResource)
+		if err != nil {
+			return schema.GroupVersionResource{}, err
+		}
+		if ok {
+			gvr := gv.WithResource(ingressResource)
+			if c.cache != nil {
+				c.cache.ingress = &gvr
+			}
+			return gvr, nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("no supported ingress api version found")
+}
+
+// ingressFromUnstructured normalizes an Ingress served by the given group version.
+func ingressFromUnstructured(u *unstructured.Unstructured, gv schema.GroupVersion) (*IngressObject, error) {
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var ing IngressObject
+	if err := json.Unmarshal(data, &ing); err != nil {
+		return nil, err
+	}
+	ing.SetGroupVersionKind(gv.WithKind(IngressKind))
+	return &ing, nil
+}
+
+// ingressToUnstructured converts a normalized Ingress back to the wire format of the given API version.
+// For v1beta1 versions, the default backend and Service backends are written as backend, serviceName and servicePort.
+func ingressToUnstructured(ing *IngressObject, apiVersion string) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(ing)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(data, &u.Object); err != nil {
+		return nil, err
+	}
+	u.SetAPIVersion(gv.String())
+	u.SetKind(IngressKind)
+	if gv.Version == IngressAPIVersion {
+		return u, nil
+	}
+	spec, ok := u.Object["spec"].(map[string]interface{})
+	if !ok {
+		return u, nil
+	}
+	if backend, ok := spec["defaultBackend"].(map[string]interface{}); ok {
+		delete(spec, "defaultBackend")
+		spec["backend"] = v1beta1IngressBackend(backend)
+	}
+	rules, _ := spec["rules"].([]interface{})
+	for _, rule := range rules {
+		paths, _, _ := unstructured.NestedSlice(rule.(map[string]interface{}), "http", "paths")
+		for _, p := range paths {
+			path := p.(map[string]interface{})
+			if backend, ok := path["backend"].(map[string]interface{}); ok {
+				path["backend"] = v1beta1IngressBackend(backend)
+			}
+		}
+		if paths != nil {
+			unstructured.SetNestedSlice(rule.(map[string]interface{}), paths, "http", "paths")
+		}
+	}
+	return u, nil
+}
+
+// v1beta1IngressBackend converts a v1 Service backend to its serviceName and servicePort representation.
+func v1beta1IngressBackend(backend map[string]interface{}) map[string]interface{} {
+	svc, ok := backend["service"].(map[string]interface{})
+	if !ok {
+		return backend
+	}
+	out := map[string]interface{}{
+		"serviceName": svc["name"],
+	}
+	if port, ok := svc["port"].(map[string]interface{}); ok {
+		if name, ok := port["name"]; ok {
+			out["servicePort"] = name
+		} else if number, ok := port["number"]; ok {
+			out["servicePort"] = number
+		}
+	}
+	return out
+}
+
 // GetNames returns all item names contained within the Collection.
 func (c *IngressCollection) GetNames() []string {
 	var names []string
@@ -153,7 +291,7 @@ func (c *IngressCollection) Get(name string) Ingress {
 	for _, item := range c.Items {
 		if item.Name == name {
 			return Ingress{
-				IngressAPIVersion,
+				item.APIVersion,
 				IngressKind,
 				&item,
 			}
@@ -173,10 +311,10 @@ func (c *IngressCollection) Search(names ...string) (collection *IngressCollecti
 			}
 		}
 	}()
-	var list v1beta1.IngressList
+	var list IngressObjectList
 	var regex *regexp.Regexp
 	var regexString string
-	var matches []v1beta1.Ingress
+	var matches []IngressObject
 	switch {
 	case len(names) <= 0:
 		return c
@@ -212,8 +350,8 @@ func (c *IngressCollection) Search(names ...string) (collection *IngressCollecti
 }
 
 func (c *IngressCollection) ingresssearchBak(names ...string) *IngressCollection {
-	var list v1beta1.IngressList
-	var matches []v1beta1.Ingress
+	var list IngressObjectList
+	var matches []IngressObject
 	for _, name := range names {
 		for _, item := range c.Items {
 			if strings.Contains(item.Name, name) {
@@ -0,0 +1,118 @@
+package ak8s
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IngressClass Constants:
+const (
+	IngressClassKind              = `IngressClass`
+	ingressClassResource          = `ingressclasses`
+	ingressClassAnnotation        = `kubernetes.io/ingress.class`
+	ingressClassDefaultAnnotation = `ingressclass.kubernetes.io/is-default-class`
+)
+
+// IngressClass contains an IngressClass resource.
+// Default is set if the IngressClass is annotated as the cluster default.
+type IngressClass struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Name       string                  `json:"name"`
+	Controller string                  `json:"controller"`
+	Default    bool                    `json:"default"`
+	Parameters *IngressClassParameters `json:"parameters,omitempty"`
+}
+
+// IngressClassParameters references the controller specific parameters of an IngressClass.
+type IngressClassParameters struct {
+	APIGroup  string `json:"apiGroup,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Scope     string `json:"scope,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// GetIngressClasses returns all IngressClasses using networking.k8s.io/v1 if served, otherwise networking.k8s.io/v1beta1.
+func (c *Client) GetIngressClasses() ([]IngressClass, error) {
+	var gvr schema.GroupVersionResource
+	for _, gv := range ingressGroupVersions {
+		if gv.Group != IngressAPIGroup {
+			continue
+		}
+		ok, err := c.serverHasResource(gv.String(), ingressClassResource)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			gvr = gv.WithResource(ingressClassResource)
+			break
+		}
+	}
+	if gvr.Empty() {
+		return nil, fmt.Errorf("ingress classes are not supported by the server")
+	}
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := dc.Resource(gvr).List(c.Options[ListOption].(*ListAction).Get())
+	if err != nil {
+		return nil, err
+	}
+	var classes []IngressClass
+	for _, item := range list.Items {
+		class := IngressClass{
+			APIVersion: gvr.GroupVersion().String(),
+			Kind:       IngressClassKind,
+			Name:       item.GetName(),
+			Default:    item.GetAnnotations()[ingressClassDefaultAnnotation] == `true`,
+		}
+		class.Controller, _, _ = unstructured.NestedString(item.Object, "spec", "controller")
+		if params, ok, _ := unstructured.NestedStringMap(item.Object, "spec", "parameters"); ok {
+			class.Parameters = &IngressClassParameters{
+				APIGroup:  params["apiGroup"],
+				Kind:      params["kind"],
+				Name:      params["name"],
+				Scope:     params["scope"],
+				Namespace: params["namespace"],
+			}
+		}
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].Name < classes[j].Name
+	})
+	return classes, nil
+}
+
+// ClassName returns the IngressClass of the Ingress from spec.ingressClassName,
+// falling back to the kubernetes.io/ingress.class annotation.
+func (r *Ingress) ClassName() string {
+	if r.IngressObject == nil {
+		return ""
+	}
+	if r.Spec.IngressClassName != "" {
+		return r.Spec.IngressClassName
+	}
+	return r.Annotations[ingressClassAnnotation]
+}
+
+// ByClass returns the Ingresses in the collection with the given IngressClass.
+func (c *IngressCollection) ByClass(class string) *IngressCollection {
+	var list IngressObjectList
+	for i := 0; i < len(c.Items); i++ {
+		ing := Ingress{c.Items[i].APIVersion, IngressKind, &c.Items[i]}
+		if ing.ClassName() == class {
+			list.Items = append(list.Items, c.Items[i])
+		}
+	}
+	return &IngressCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}
+}
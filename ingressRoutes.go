@@ -0,0 +1,216 @@
+package ak8s
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Ingress Route Issue Constants:
+const (
+	RouteIssueServiceNotFound = `service not found`
+	RouteIssuePortNotFound    = `service port not found`
+)
+
+// pathTypeImplementationSpecific is the path type reported for routes without one, eg. from v1beta1 Ingresses.
+const pathTypeImplementationSpecific = `ImplementationSpecific`
+
+// IngressRoute is a single flattened host/path to backend service/port row from an Ingress.
+// Default is set for the Ingress default backend, which matches any host and path.
+// Resource is set instead of Service and Port for Resource backends, eg. StorageBucket/static-assets.
+type IngressRoute struct {
+	Namespace string   `json:"namespace"`
+	Ingress   string   `json:"ingress"`
+	Host      string   `json:"host"`
+	Path      string   `json:"path"`
+	PathType  string   `json:"pathType,omitempty"`
+	Service   string   `json:"service"`
+	Port      string   `json:"port"`
+	Resource  string   `json:"resource,omitempty"`
+	TLS       bool     `json:"tls"`
+	Default   bool     `json:"default"`
+	Issues    []string `json:"issues,omitempty"`
+}
+
+// IngressConflict contains a host/path served by more than one Ingress.
+// PathTypes is set when the conflicting routes use different path types, a route without one is listed as ImplementationSpecific.
+type IngressConflict struct {
+	Host      string   `json:"host"`
+	Path      string   `json:"path"`
+	PathTypes []string `json:"pathTypes,omitempty"`
+	Ingresses []string `json:"ingresses"`
+}
+
+// IngressRoutingTable contains the flattened routes of a collection of Ingresses
+// along with any host/path conflicts found across them.
+type IngressRoutingTable struct {
+	Routes    []IngressRoute    `json:"routes"`
+	Conflicts []IngressConflict `json:"conflicts,omitempty"`
+}
+
+// Unresolved returns the routes whose backend could not be resolved to a service port.
+func (t *IngressRoutingTable) Unresolved() []IngressRoute {
+	var routes []IngressRoute
+	for _, route := range t.Routes {
+		if len(route.Issues) > 0 {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// GetIngressRoutingTable returns the IngressRoutingTable for all Ingresses and Services
+// in the current namespace set on the client or across all namespaces if not set.
+func (c *Client) GetIngressRoutingTable() (*IngressRoutingTable, error) {
+	ingresses, err := c.GetAllIngress()
+	if err != nil {
+		return &IngressRoutingTable{}, err
+	}
+	services, err := c.GetAllServices()
+	if err != nil {
+		return &IngressRoutingTable{}, err
+	}
+	return ingresses.RoutingTable(services), nil
+}
+
+// Routes returns the flattened host/path to backend rows for the Ingress.
+func (r *Ingress) Routes() []IngressRoute {
+	if r.IngressObject == nil {
+		return nil
+	}
+	tlsHosts := make(map[string]bool)
+	for _, tls := range r.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+	var routes []IngressRoute
+	if r.Spec.DefaultBackend != nil {
+		routes = append(routes, IngressRoute{
+			Namespace: r.Namespace,
+			Ingress:   r.Name,
+			Path:      `/`,
+			Service:   r.Spec.DefaultBackend.ServiceName(),
+			Port:      r.Spec.DefaultBackend.ServicePort(),
+			Resource:  backendResource(r.Spec.DefaultBackend),
+			Default:   true,
+		})
+	}
+	for _, rule := range r.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			path := p.Path
+			if path == "" {
+				path = `/`
+			}
+			routes = append(routes, IngressRoute{
+				Namespace: r.Namespace,
+				Ingress:   r.Name,
+				Host:      rule.Host,
+				Path:      path,
+				PathType:  p.PathType,
+				Service:   p.Backend.ServiceName(),
+				Port:      p.Backend.ServicePort(),
+				Resource:  backendResource(&p.Backend),
+				TLS:       tlsHosts[rule.Host],
+			})
+		}
+	}
+	return routes
+}
+
+// Routes returns the flattened host/path to backend rows for all Ingresses in the collection.
+func (c *IngressCollection) Routes() []IngressRoute {
+	var routes []IngressRoute
+	for i := 0; i < len(c.Items); i++ {
+		ing := Ingress{c.Items[i].APIVersion, IngressKind, &c.Items[i]}
+		routes = append(routes, ing.Routes()...)
+	}
+	return routes
+}
+
+// RoutingTable returns the IngressRoutingTable for the collection, resolving each backend against services
+// to flag missing services or ports, and detecting host/path conflicts across Ingresses.
+// If services is nil, backends are not resolved.
+func (c *IngressCollection) RoutingTable(services *ServiceCollection) *IngressRoutingTable {
+	table := IngressRoutingTable{
+		Routes: c.Routes(),
+	}
+	if services != nil && services.ServiceList != nil {
+		for i := range table.Routes {
+			if table.Routes[i].Resource != "" {
+				continue
+			}
+			table.Routes[i].Issues = resolveRouteBackend(&table.Routes[i], services)
+		}
+	}
+	type hostPath struct {
+		host string
+		path string
+	}
+	owners := make(map[hostPath][]string)
+	pathTypes := make(map[hostPath][]string)
+	var order []hostPath
+	for _, route := range table.Routes {
+		if route.Default {
+			continue
+		}
+		key := hostPath{route.Host, route.Path}
+		owner := route.Namespace + `/` + route.Ingress
+		if _, ok := owners[key]; !ok {
+			order = append(order, key)
+		}
+		owners[key] = appendUnique(owners[key], owner)
+		pathType := route.PathType
+		if pathType == "" {
+			pathType = pathTypeImplementationSpecific
+		}
+		pathTypes[key] = appendUnique(pathTypes[key], pathType)
+	}
+	for _, key := range order {
+		if len(owners[key]) > 1 {
+			host := key.host
+			if host == "" {
+				host = `*`
+			}
+			sort.Strings(owners[key])
+			conflict := IngressConflict{
+				Host:      host,
+				Path:      key.path,
+				Ingresses: owners[key],
+			}
+			if len(pathTypes[key]) > 1 {
+				sort.Strings(pathTypes[key])
+				conflict.PathTypes = pathTypes[key]
+			}
+			table.Conflicts = append(table.Conflicts, conflict)
+		}
+	}
+	return &table
+}
+
+// resolveRouteBackend returns any issues resolving the route backend against services.
+func resolveRouteBackend(route *IngressRoute, services *ServiceCollection) []string {
+	for _, svc := range services.Items {
+		if svc.Namespace != route.Namespace || svc.Name != route.Service {
+			continue
+		}
+		port := intstr.Parse(route.Port)
+		for _, sp := range svc.Spec.Ports {
+			if (port.Type == intstr.Int && sp.Port == port.IntVal) || (port.Type == intstr.String && sp.Name == port.StrVal) {
+				return nil
+			}
+		}
+		return []string{RouteIssuePortNotFound}
+	}
+	return []string{RouteIssueServiceNotFound}
+}
+
+func backendResource(b *IngressBackend) string {
+	if b == nil || b.Resource == nil {
+		return ""
+	}
+	return b.Resource.Kind + `/` + b.Resource.Name
+}
@@ -0,0 +1,204 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// IngressObject is an Ingress normalized into the networking.k8s.io/v1 representation,
+// regardless of whether it was served by networking.k8s.io/v1, networking.k8s.io/v1beta1 or extensions/v1beta1.
+type IngressObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IngressSpec   `json:"spec,omitempty"`
+	Status            IngressStatus `json:"status,omitempty"`
+}
+
+// IngressObjectList contains a list of normalized Ingresses.
+type IngressObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IngressObject `json:"items"`
+}
+
+// IngressSpec is the normalized spec of an Ingress.
+type IngressSpec struct {
+	IngressClassName string          `json:"ingressClassName,omitempty"`
+	DefaultBackend   *IngressBackend `json:"defaultBackend,omitempty"`
+	TLS              []IngressTLS    `json:"tls,omitempty"`
+	Rules            []IngressRule   `json:"rules,omitempty"`
+}
+
+// IngressTLS describes the TLS configuration of an Ingress.
+type IngressTLS struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+// IngressRule maps the paths under a host to backends.
+type IngressRule struct {
+	Host string                `json:"host,omitempty"`
+	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
+}
+
+// HTTPIngressRuleValue contains the http paths of an IngressRule.
+type HTTPIngressRuleValue struct {
+	Paths []HTTPIngressPath `json:"paths"`
+}
+
+// HTTPIngressPath maps a path to a backend.
+type HTTPIngressPath struct {
+	Path     string         `json:"path,omitempty"`
+	PathType string         `json:"pathType,omitempty"`
+	Backend  IngressBackend `json:"backend"`
+}
+
+// IngressBackend describes a Service or Resource backend of an Ingress.
+type IngressBackend struct {
+	Service  *IngressServiceBackend        `json:"service,omitempty"`
+	Resource *v1.TypedLocalObjectReference `json:"resource,omitempty"`
+}
+
+// IngressServiceBackend references a Service port as an Ingress backend.
+type IngressServiceBackend struct {
+	Name string             `json:"name"`
+	Port ServiceBackendPort `json:"port,omitempty"`
+}
+
+// ServiceBackendPort references a Service port by name or number.
+type ServiceBackendPort struct {
+	Name   string `json:"name,omitempty"`
+	Number int32  `json:"number,omitempty"`
+}
+
+// IngressStatus contains the status of an Ingress.
+type IngressStatus struct {
+	LoadBalancer v1.LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// String returns the port name or number.
+func (p ServiceBackendPort) String() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return strconv.Itoa(int(p.Number))
+}
+
+// ServiceName returns the backend Service name, or an empty string for Resource backends.
+func (b *IngressBackend) ServiceName() string {
+	if b == nil || b.Service == nil {
+		return ""
+	}
+	return b.Service.Name
+}
+
+// ServicePort returns the backend Service port name or number, or an empty string for Resource backends.
+func (b *IngressBackend) ServicePort() string {
+	if b == nil || b.Service == nil {
+		return ""
+	}
+	return b.Service.Port.String()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both the v1 and v1beta1 backend representations.
+func (b *IngressBackend) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Service     *IngressServiceBackend        `json:"service"`
+		Resource    *v1.TypedLocalObjectReference `json:"resource"`
+		ServiceName string                        `json:"serviceName"`
+		ServicePort *intstr.IntOrString           `json:"servicePort"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	b.Service, b.Resource = wire.Service, wire.Resource
+	if b.Service == nil && wire.ServiceName != "" {
+		b.Service = &IngressServiceBackend{Name: wire.ServiceName}
+		if wire.ServicePort != nil {
+			if wire.ServicePort.Type == intstr.String {
+				b.Service.Port.Name = wire.ServicePort.StrVal
+			} else {
+				b.Service.Port.Number = wire.ServicePort.IntVal
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the v1beta1 backend field as the default backend.
+func (s *IngressSpec) UnmarshalJSON(data []byte) error {
+	type ingressSpec IngressSpec
+	var wire struct {
+		ingressSpec
+		Backend *IngressBackend `json:"backend"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*s = IngressSpec(wire.ingressSpec)
+	if s.DefaultBackend == nil {
+		s.DefaultBackend = wire.Backend
+	}
+	return nil
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *IngressObject) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopy returns a deep copy of the IngressObject.
+func (in *IngressObject) DeepCopy() *IngressObject {
+	if in == nil {
+		return nil
+	}
+	out := &IngressObject{
+		TypeMeta: in.TypeMeta,
+		Spec:     *in.Spec.DeepCopy(),
+	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.LoadBalancer.DeepCopyInto(&out.Status.LoadBalancer)
+	return out
+}
+
+// DeepCopy returns a deep copy of the IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	out := &IngressSpec{
+		IngressClassName: in.IngressClassName,
+		DefaultBackend:   in.DefaultBackend.DeepCopy(),
+	}
+	for _, tls := range in.TLS {
+		out.TLS = append(out.TLS, IngressTLS{append([]string(nil), tls.Hosts...), tls.SecretName})
+	}
+	for _, rule := range in.Rules {
+		r := IngressRule{Host: rule.Host}
+		if rule.HTTP != nil {
+			r.HTTP = &HTTPIngressRuleValue{}
+			for _, p := range rule.HTTP.Paths {
+				r.HTTP.Paths = append(r.HTTP.Paths, HTTPIngressPath{p.Path, p.PathType, *p.Backend.DeepCopy()})
+			}
+		}
+		out.Rules = append(out.Rules, r)
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the IngressBackend.
+func (in *IngressBackend) DeepCopy() *IngressBackend {
+	if in == nil {
+		return nil
+	}
+	out := &IngressBackend{
+		Resource: in.Resource.DeepCopy(),
+	}
+	if in.Service != nil {
+		svc := *in.Service
+		out.Service = &svc
+	}
+	return out
+}
@@ -60,12 +60,16 @@ func CreateClientSetFromConfig(configPath string) (*kubernetes.Clientset, error)
 
 // CreateUserClientSet returns a clientset using username/password values.
 func CreateUserClientSet(host, username, password string, insecure bool) (*kubernetes.Clientset, error) {
+	return kubernetes.NewForConfig(GetUserConfig(host, username, password, insecure))
+}
+
+// GetUserConfig returns a rest.Config using username/password values.
+func GetUserConfig(host, username, password string, insecure bool) *rest.Config {
 	tls := rest.TLSClientConfig{Insecure: insecure}
-	config := rest.Config{
+	return &rest.Config{
 		Host:            host,
 		Username:        username,
 		Password:        password,
 		TLSClientConfig: tls,
 	}
-	return kubernetes.NewForConfig(&config)
 }
@@ -0,0 +1,235 @@
+package ak8s
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// manifestKindOrder is the order in which manifest kinds are created, any other kinds are created last.
+var manifestKindOrder = []string{
+	`Namespace`,
+	`CustomResourceDefinition`,
+	`PriorityClass`,
+	`StorageClass`,
+	`ServiceAccount`,
+	`Secret`,
+	`ConfigMap`,
+	`PersistentVolume`,
+	`PersistentVolumeClaim`,
+	`ClusterRole`,
+	`ClusterRoleBinding`,
+	`Role`,
+	`RoleBinding`,
+	`Service`,
+	`DaemonSet`,
+	`Pod`,
+	`ReplicaSet`,
+	`Deployment`,
+	`StatefulSet`,
+	`Job`,
+	`CronJob`,
+	`IngressClass`,
+	`Ingress`,
+}
+
+// manifestExtensions are the file extensions read when loading manifests from a directory.
+var manifestExtensions = map[string]bool{
+	`.yaml`: true,
+	`.yml`:  true,
+	`.json`: true,
+}
+
+// Manifest is a single object decoded from a YAML or JSON manifest.
+// Source identifies where the object was read from, eg. deploy/app.yaml#2 for the second document of the file.
+type Manifest struct {
+	Source string
+	Object *unstructured.Unstructured
+}
+
+// ManifestResult contains the result of creating or applying a Manifest.
+type ManifestResult struct {
+	Source    string
+	Kind      string
+	Namespace string
+	Name      string
+	Object    *unstructured.Unstructured
+	Err       error
+}
+
+// Typed converts the Manifest object to its typed representation, eg. *v1.Deployment, for kinds known to client-go.
+func (m *Manifest) Typed() (runtime.Object, error) {
+	obj, err := scheme.Scheme.New(m.Object.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m.Object.Object, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// String returns the Manifest as Kind/Name along with its source.
+func (m *Manifest) String() string {
+	return fmt.Sprintf("%v/%v (%v)", m.Object.GetKind(), m.Object.GetName(), m.Source)
+}
+
+// LoadManifests decodes all YAML or JSON documents from r. Empty documents are skipped and List kinds are expanded into their items.
+func LoadManifests(r io.Reader) ([]Manifest, error) {
+	return loadManifests(r, `-`)
+}
+
+// LoadManifestFiles decodes all YAML or JSON documents from the given files or directories.
+// Directories are walked recursively reading all .yaml, .yml and .json files in lexical order.
+func LoadManifestFiles(paths ...string) ([]Manifest, error) {
+	var manifests []Manifest
+	for _, path := range paths {
+		var files []string
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case info.IsDir():
+				return nil
+			case file == path || manifestExtensions[strings.ToLower(filepath.Ext(file))]:
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return manifests, err
+		}
+		for _, file := range files {
+			f, err := os.Open(file)
+			if err != nil {
+				return manifests, err
+			}
+			m, err := loadManifests(f, file)
+			f.Close()
+			if err != nil {
+				return manifests, err
+			}
+			manifests = append(manifests, m...)
+		}
+	}
+	return manifests, nil
+}
+
+func loadManifests(r io.Reader, source string) ([]Manifest, error) {
+	var manifests []Manifest
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for doc := 1; ; doc++ {
+		var obj map[string]interface{}
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			return manifests, nil
+		}
+		if err != nil {
+			return manifests, fmt.Errorf("%v#%d: %v", source, doc, err)
+		}
+		if len(obj) < 1 {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: obj}
+		src := fmt.Sprintf("%v#%d", source, doc)
+		if u.GetKind() == "" || u.GetAPIVersion() == "" {
+			return manifests, fmt.Errorf("%v: object apiVersion and kind must be set", src)
+		}
+		if !u.IsList() {
+			manifests = append(manifests, Manifest{src, u})
+			continue
+		}
+		list, err := u.ToList()
+		if err != nil {
+			return manifests, fmt.Errorf("%v: %v", src, err)
+		}
+		for i := range list.Items {
+			manifests = append(manifests, Manifest{fmt.Sprintf("%v[%d]", src, i), &list.Items[i]})
+		}
+	}
+}
+
+// SortManifests orders manifests for creation, Namespaces and CustomResourceDefinitions first followed by
+// the resources depending on them. The relative order of manifests of the same kind is kept.
+func SortManifests(manifests []Manifest) {
+	order := make(map[string]int, len(manifestKindOrder))
+	for i, kind := range manifestKindOrder {
+		order[kind] = i
+	}
+	rank := func(m Manifest) int {
+		if i, ok := order[m.Object.GetKind()]; ok {
+			return i
+		}
+		return len(manifestKindOrder)
+	}
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return rank(manifests[i]) < rank(manifests[j])
+	})
+}
+
+// CreateManifests sorts and creates the manifests, returning a result for each.
+// A failed object does not stop the remaining objects from being created.
+func (c *Client) CreateManifests(manifests []Manifest) []ManifestResult {
+	return c.eachManifest(manifests, func(mapper meta.RESTMapper, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		ri, err := c.mappedResource(mapper, u.GroupVersionKind(), u.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		return ri.Create(u, c.Options[CreateOption].(*CreateAction).Get())
+	})
+}
+
+// ApplyManifests sorts and applies the manifests using server-side apply, returning a result for each.
+// A failed object does not stop the remaining objects from being applied.
+func (c *Client) ApplyManifests(manifests []Manifest, fieldManager string, force bool) []ManifestResult {
+	return c.eachManifest(manifests, func(mapper meta.RESTMapper, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		return c.apply(mapper, u, fieldManager, force)
+	})
+}
+
+// eachManifest sorts the manifests and calls fn for each one. Discovery is refreshed once if a kind is not found,
+// eg. a custom resource whose CustomResourceDefinition was created earlier in the same batch.
+func (c *Client) eachManifest(manifests []Manifest, fn func(meta.RESTMapper, *unstructured.Unstructured) (*unstructured.Unstructured, error)) []ManifestResult {
+	sorted := append([]Manifest{}, manifests...)
+	SortManifests(sorted)
+	results := make([]ManifestResult, 0, len(sorted))
+	mapper, mapperErr := c.restMapper()
+	var refreshed bool
+	for _, m := range sorted {
+		result := ManifestResult{
+			Source:    m.Source,
+			Kind:      m.Object.GetKind(),
+			Namespace: m.Object.GetNamespace(),
+			Name:      m.Object.GetName(),
+		}
+		if mapperErr != nil {
+			result.Err = mapperErr
+			results = append(results, result)
+			continue
+		}
+		obj, err := fn(mapper, m.Object.DeepCopy())
+		if meta.IsNoMatchError(err) && !refreshed {
+			refreshed = true
+			if mapper, mapperErr = c.refreshRESTMapper(); mapperErr == nil {
+				obj, err = fn(mapper, m.Object.DeepCopy())
+			} else {
+				err = mapperErr
+			}
+		}
+		result.Object, result.Err = obj, err
+		if obj != nil {
+			result.Namespace = obj.GetNamespace()
+		}
+		results = append(results, result)
+	}
+	return results
+}
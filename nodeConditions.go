@@ -0,0 +1,249 @@
+package ak8s
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/api/core/v1"
+)
+
+// Node Role Constants:
+const (
+	nodeRoleLabelPrefix = `node-role.kubernetes.io/`
+	nodeRoleLabel       = `kubernetes.io/role`
+)
+
+// Condition returns the NodeCondition for the given type or nil if not found.
+func (r *Node) Condition(condType v1.NodeConditionType) *v1.NodeCondition {
+	if r.Node == nil {
+		return nil
+	}
+	for i := range r.Status.Conditions {
+		if r.Status.Conditions[i].Type == condType {
+			return &r.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsReady returns true if the Node has a Ready condition set to True.
+func (r *Node) IsReady() bool {
+	return r.conditionTrue(v1.NodeReady)
+}
+
+// ReadyStatus returns the status of the Node as displayed by kubectl, eg. Ready, NotReady,SchedulingDisabled.
+func (r *Node) ReadyStatus() string {
+	if r.Node == nil {
+		return ""
+	}
+	status := `Unknown`
+	if cond := r.Condition(v1.NodeReady); cond != nil {
+		switch cond.Status {
+		case v1.ConditionTrue:
+			status = `Ready`
+		case v1.ConditionFalse:
+			status = `NotReady`
+		}
+	}
+	if r.Spec.Unschedulable {
+		status += `,SchedulingDisabled`
+	}
+	return status
+}
+
+// MemoryPressure returns true if the Node reports MemoryPressure.
+func (r *Node) MemoryPressure() bool {
+	return r.conditionTrue(v1.NodeMemoryPressure)
+}
+
+// DiskPressure returns true if the Node reports DiskPressure.
+func (r *Node) DiskPressure() bool {
+	return r.conditionTrue(v1.NodeDiskPressure)
+}
+
+// PIDPressure returns true if the Node reports PIDPressure.
+func (r *Node) PIDPressure() bool {
+	return r.conditionTrue(v1.NodePIDPressure)
+}
+
+// HasPressure returns true if the Node reports any Memory, Disk or PID pressure.
+func (r *Node) HasPressure() bool {
+	return r.MemoryPressure() || r.DiskPressure() || r.PIDPressure()
+}
+
+func (r *Node) conditionTrue(condType v1.NodeConditionType) bool {
+	cond := r.Condition(condType)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
+
+// Taints returns the taints set on the Node.
+func (r *Node) Taints() []v1.Taint {
+	if r.Node == nil {
+		return nil
+	}
+	return r.Spec.Taints
+}
+
+// HasTaint returns true if the Node has a taint with the given key and any of the given effects.
+// If no effects are given, any effect matches.
+func (r *Node) HasTaint(key string, effects ...v1.TaintEffect) bool {
+	for _, taint := range r.Taints() {
+		if taint.Key != key {
+			continue
+		}
+		if len(effects) < 1 {
+			return true
+		}
+		for _, effect := range effects {
+			if taint.Effect == effect {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Roles returns the sorted roles of the Node from its node-role.kubernetes.io/<role> and kubernetes.io/role labels.
+func (r *Node) Roles() []string {
+	if r.Node == nil {
+		return nil
+	}
+	roles := make(map[string]bool)
+	for k, v := range r.Labels {
+		switch {
+		case strings.HasPrefix(k, nodeRoleLabelPrefix):
+			if role := strings.TrimPrefix(k, nodeRoleLabelPrefix); role != "" {
+				roles[role] = true
+			}
+		case k == nodeRoleLabel && v != "":
+			roles[v] = true
+		}
+	}
+	var list []string
+	for role := range roles {
+		list = append(list, role)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// HasRole returns true if the Node has the given role.
+func (r *Node) HasRole(role string) bool {
+	for _, ro := range r.Roles() {
+		if ro == role {
+			return true
+		}
+	}
+	return false
+}
+
+// KubeletVersion returns the kubelet version reported by the Node.
+func (r *Node) KubeletVersion() string {
+	if r.Node == nil {
+		return ""
+	}
+	return r.Status.NodeInfo.KubeletVersion
+}
+
+// OS returns the operating system reported by the Node.
+func (r *Node) OS() string {
+	if r.Node == nil {
+		return ""
+	}
+	return r.Status.NodeInfo.OperatingSystem
+}
+
+// Arch returns the architecture reported by the Node.
+func (r *Node) Arch() string {
+	if r.Node == nil {
+		return ""
+	}
+	return r.Status.NodeInfo.Architecture
+}
+
+// OSArch returns the operating system and architecture of the Node, eg. linux/amd64.
+func (r *Node) OSArch() string {
+	return r.OS() + `/` + r.Arch()
+}
+
+// Addresses returns the Node addresses for the given address type.
+func (r *Node) Addresses(addrType v1.NodeAddressType) []string {
+	if r.Node == nil {
+		return nil
+	}
+	var addrs []string
+	for _, addr := range r.Status.Addresses {
+		if addr.Type == addrType {
+			addrs = append(addrs, addr.Address)
+		}
+	}
+	return addrs
+}
+
+// InternalIP returns the first internal IP address of the Node.
+func (r *Node) InternalIP() string {
+	if addrs := r.Addresses(v1.NodeInternalIP); len(addrs) > 0 {
+		return addrs[0]
+	}
+	return ""
+}
+
+// ExternalIP returns the first external IP address of the Node.
+func (r *Node) ExternalIP() string {
+	if addrs := r.Addresses(v1.NodeExternalIP); len(addrs) > 0 {
+		return addrs[0]
+	}
+	return ""
+}
+
+// Ready returns the Nodes in the collection that are Ready.
+func (c *NodeCollection) Ready() *NodeCollection {
+	return c.filter(func(node *Node) bool {
+		return node.IsReady()
+	})
+}
+
+// NotReady returns the Nodes in the collection that are not Ready.
+func (c *NodeCollection) NotReady() *NodeCollection {
+	return c.filter(func(node *Node) bool {
+		return !node.IsReady()
+	})
+}
+
+// WithPressure returns the Nodes in the collection reporting any Memory, Disk or PID pressure.
+func (c *NodeCollection) WithPressure() *NodeCollection {
+	return c.filter(func(node *Node) bool {
+		return node.HasPressure()
+	})
+}
+
+// WithTaint returns the Nodes in the collection that have a taint with the given key and any of the given effects.
+func (c *NodeCollection) WithTaint(key string, effects ...v1.TaintEffect) *NodeCollection {
+	return c.filter(func(node *Node) bool {
+		return node.HasTaint(key, effects...)
+	})
+}
+
+// ByRole returns the Nodes in the collection that have the given role.
+func (c *NodeCollection) ByRole(role string) *NodeCollection {
+	return c.filter(func(node *Node) bool {
+		return node.HasRole(role)
+	})
+}
+
+func (c *NodeCollection) filter(fn func(*Node) bool) *NodeCollection {
+	var list v1.NodeList
+	var matches []v1.Node
+	for i := 0; i < len(c.Items); i++ {
+		node := Node{NodeAPIVersion, NodeKind, &c.Items[i]}
+		if fn(&node) {
+			matches = append(matches, c.Items[i])
+		}
+	}
+	list.Items = matches
+	return &NodeCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}
+}
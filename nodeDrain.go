@@ -0,0 +1,266 @@
+package ak8s
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Drain Constants:
+const (
+	mirrorPodAnnotation = `kubernetes.io/config.mirror`
+	defaultDrainTimeout = 5 * time.Minute
+	drainRetryInterval  = 5 * time.Second
+)
+
+// DrainOptions contains the Options used when draining a Node.
+// GracePeriodSeconds overrides the Pod termination grace period when greater than 0, otherwise the Pod default is used.
+// Force allows evicting Pods that are not managed by a controller.
+// DeleteLocalData allows evicting Pods using emptyDir volumes.
+// Timeout defaults to 5 minutes if not set.
+// When DryRun is set, Pods that would be evicted are reported but the Node is neither cordoned nor drained.
+type DrainOptions struct {
+	GracePeriodSeconds int64
+	Timeout            time.Duration
+	Force              bool
+	DeleteLocalData    bool
+	DryRun             bool
+	Progress           func(DrainProgress)
+}
+
+// DrainProgress describes the progress of a Drain for a Pod on a Node.
+type DrainProgress struct {
+	Node      string
+	Namespace string
+	Pod       string
+	Message   string
+}
+
+// Cordon marks the Node as unschedulable.
+func (r *Node) Cordon(c *Client) error {
+	return r.setUnschedulable(c, true)
+}
+
+// Uncordon marks the Node as schedulable.
+func (r *Node) Uncordon(c *Client) error {
+	return r.setUnschedulable(c, false)
+}
+
+func (r *Node) setUnschedulable(c *Client, unschedulable bool) error {
+	if r.Node == nil {
+		return fmt.Errorf("no node specified")
+	}
+	if r.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	node := &v1.Node{}
+	if err := c.patchObject(c.CS.CoreV1().RESTClient(), `nodes`, "", r.Name, types.StrategicMergePatchType, patch, node); err != nil {
+		return err
+	}
+	node.APIVersion, node.Kind = r.APIVersion, r.Kind
+	r.Node = node
+	return nil
+}
+
+// Drain cordons the Node and evicts its Pods using the eviction API, honoring PodDisruptionBudgets.
+// Mirror Pods and Pods managed by a DaemonSet are skipped.
+func (r *Node) Drain(c *Client, opts *DrainOptions) error {
+	if r.Node == nil {
+		return fmt.Errorf("no node specified")
+	}
+	if opts == nil {
+		opts = &DrainOptions{}
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	progress := func(pod *v1.Pod, msg string) {
+		if opts.Progress == nil {
+			return
+		}
+		p := DrainProgress{Node: r.Name, Message: msg}
+		if pod != nil {
+			p.Namespace, p.Pod = pod.Namespace, pod.Name
+		}
+		opts.Progress(p)
+	}
+	pods, err := r.drainablePods(c, opts, progress)
+	if err != nil {
+		return err
+	}
+	if opts.DryRun {
+		for i := range pods {
+			progress(&pods[i], `would evict (dry run)`)
+		}
+		return nil
+	}
+	if err := r.Cordon(c); err != nil {
+		return err
+	}
+	progress(nil, `cordoned`)
+	deadline := time.Now().Add(timeout)
+	var errd string
+	for i := range pods {
+		if err := evictPod(c, &pods[i], opts.GracePeriodSeconds, deadline, progress); err != nil {
+			errd += (fmt.Sprintf("%v/%v: %v", pods[i].Namespace, pods[i].Name, err) + fmt.Sprintf("\n"))
+		}
+	}
+	if errd != "" {
+		return fmt.Errorf("%v", errd)
+	}
+	progress(nil, `drained`)
+	return nil
+}
+
+// drainablePods returns the Pods on the Node to evict, skipping mirror and DaemonSet managed Pods.
+func (r *Node) drainablePods(c *Client, opts *DrainOptions, progress func(*v1.Pod, string)) ([]v1.Pod, error) {
+	listOpts := c.Options[ListOption].(*ListAction).Get()
+	listOpts.FieldSelector = fields.SelectorFromSet(fields.Set{"spec.nodeName": r.Name}).String()
+	list, err := c.CS.CoreV1().Pods(metav1.NamespaceAll).List(listOpts)
+	if err != nil {
+		return nil, err
+	}
+	var pods []v1.Pod
+	var errd string
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			progress(pod, `skipping mirror pod`)
+			continue
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			pods = append(pods, *pod)
+			continue
+		}
+		ref := metav1.GetControllerOf(pod)
+		if ref != nil && ref.Kind == DaemonSetKind {
+			progress(pod, `skipping daemonset managed pod`)
+			continue
+		}
+		if ref == nil && !opts.Force {
+			errd += (fmt.Sprintf("%v/%v: pod not managed by a controller, use Force to evict", pod.Namespace, pod.Name) + fmt.Sprintf("\n"))
+			continue
+		}
+		if hasLocalStorage(pod) && !opts.DeleteLocalData {
+			errd += (fmt.Sprintf("%v/%v: pod has local storage, use DeleteLocalData to evict", pod.Namespace, pod.Name) + fmt.Sprintf("\n"))
+			continue
+		}
+		pods = append(pods, *pod)
+	}
+	if errd != "" {
+		return nil, fmt.Errorf("%v", errd)
+	}
+	return pods, nil
+}
+
+// evictPod evicts the Pod, retrying while blocked by a PodDisruptionBudget, and waits for it to be deleted.
+func evictPod(c *Client, pod *v1.Pod, gracePeriod int64, deadline time.Time, progress func(*v1.Pod, string)) error {
+	eviction := &policy.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			DryRun: c.Options[DeleteOption].(*DeleteAction).Get().DryRun,
+		},
+	}
+	if gracePeriod > 0 {
+		grace := gracePeriod
+		eviction.DeleteOptions.GracePeriodSeconds = &grace
+	}
+	progress(pod, `evicting`)
+	for {
+		err := c.CS.CoreV1().Pods(pod.Namespace).Evict(eviction)
+		switch {
+		case err == nil:
+		case errors.IsNotFound(err):
+			progress(pod, `evicted`)
+			return nil
+		case errors.IsTooManyRequests(err):
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timed out waiting for eviction: %v", err)
+			}
+			progress(pod, `eviction blocked by disruption budget, retrying`)
+			time.Sleep(drainRetryInterval)
+			continue
+		default:
+			return err
+		}
+		break
+	}
+	if c.DryRun() {
+		progress(pod, `evicted (server dry run)`)
+		return nil
+	}
+	err := wait.PollImmediate(time.Second, time.Until(deadline), func() (bool, error) {
+		p, err := c.CS.CoreV1().Pods(pod.Namespace).Get(pod.Name, c.Options[GetOption].(*GetAction).Get())
+		switch {
+		case errors.IsNotFound(err):
+			return true, nil
+		case err != nil:
+			return false, err
+		case p.UID != pod.UID:
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for pod deletion: %v", err)
+	}
+	progress(pod, `evicted`)
+	return nil
+}
+
+func hasLocalStorage(pod *v1.Pod) bool {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Cordon marks all Nodes in the collection as unschedulable.
+func (c *NodeCollection) Cordon(client *Client) error {
+	return c.eachNode(func(node *Node) error {
+		return node.Cordon(client)
+	})
+}
+
+// Uncordon marks all Nodes in the collection as schedulable.
+func (c *NodeCollection) Uncordon(client *Client) error {
+	return c.eachNode(func(node *Node) error {
+		return node.Uncordon(client)
+	})
+}
+
+// Drain drains each Node in the collection in turn.
+func (c *NodeCollection) Drain(client *Client, opts *DrainOptions) error {
+	return c.eachNode(func(node *Node) error {
+		return node.Drain(client, opts)
+	})
+}
+
+func (c *NodeCollection) eachNode(fn func(*Node) error) error {
+	var errd string
+	for i := 0; i < len(c.Items); i++ {
+		node := Node{NodeAPIVersion, NodeKind, &c.Items[i]}
+		if err := fn(&node); err != nil {
+			errd += (fmt.Sprintf("%v: %v", node.Name, err) + fmt.Sprintf("\n"))
+		}
+		c.Items[i] = *node.Node
+	}
+	if errd != "" {
+		return fmt.Errorf("%v", errd)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package ak8s
+
+import (
+	"sort"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceAllocation contains the capacity, allocatable and allocated amounts for a single resource.
+type ResourceAllocation struct {
+	Name        v1.ResourceName
+	Capacity    resource.Quantity
+	Allocatable resource.Quantity
+	Requests    resource.Quantity
+	Limits      resource.Quantity
+}
+
+// RequestsPercent returns the requested amount as a percentage of allocatable.
+func (a *ResourceAllocation) RequestsPercent() float64 {
+	return percentOf(a.Requests, a.Allocatable)
+}
+
+// LimitsPercent returns the limited amount as a percentage of allocatable.
+// Values over 100 indicate the resource is overcommitted.
+func (a *ResourceAllocation) LimitsPercent() float64 {
+	return percentOf(a.Limits, a.Allocatable)
+}
+
+// NodeResources contains the allocated resources for a Node, as reported by kubectl describe node.
+// For a cluster rollup, Node is empty and the amounts are summed across all Nodes.
+type NodeResources struct {
+	Node      string
+	Pods      int
+	Resources map[v1.ResourceName]*ResourceAllocation
+}
+
+// ResourceNames returns the sorted resource names contained in NodeResources.
+func (n *NodeResources) ResourceNames() []v1.ResourceName {
+	var names []v1.ResourceName
+	for name := range n.Resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// Get returns the ResourceAllocation for the given resource name, eg. v1.ResourceCPU.
+func (n *NodeResources) Get(name v1.ResourceName) *ResourceAllocation {
+	if a, ok := n.Resources[name]; ok {
+		return a
+	}
+	return &ResourceAllocation{Name: name}
+}
+
+func (n *NodeResources) allocation(name v1.ResourceName) *ResourceAllocation {
+	if n.Resources == nil {
+		n.Resources = make(map[v1.ResourceName]*ResourceAllocation)
+	}
+	a, ok := n.Resources[name]
+	if !ok {
+		a = &ResourceAllocation{Name: name}
+		n.Resources[name] = a
+	}
+	return a
+}
+
+func (n *NodeResources) add(other *NodeResources) {
+	n.Pods += other.Pods
+	for name, o := range other.Resources {
+		a := n.allocation(name)
+		a.Capacity.Add(o.Capacity)
+		a.Allocatable.Add(o.Allocatable)
+		a.Requests.Add(o.Requests)
+		a.Limits.Add(o.Limits)
+	}
+}
+
+// Resources returns the allocated resources for the Node using the given Pods.
+// Pods not scheduled on the Node and terminated Pods are ignored,
+// so a PodCollection across all namespaces can be used.
+func (r *Node) Resources(pods *PodCollection) *NodeResources {
+	res := NodeResources{Node: r.Name}
+	if r.Node == nil {
+		return &res
+	}
+	for name, q := range r.Status.Capacity {
+		res.allocation(name).Capacity = q.DeepCopy()
+	}
+	for name, q := range r.Status.Allocatable {
+		res.allocation(name).Allocatable = q.DeepCopy()
+	}
+	if pods == nil || pods.PodList == nil {
+		return &res
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != r.Name || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		res.Pods++
+		reqs, limits := podRequestsAndLimits(pod)
+		for name, q := range reqs {
+			a := res.allocation(name)
+			a.Requests.Add(q)
+		}
+		for name, q := range limits {
+			a := res.allocation(name)
+			a.Limits.Add(q)
+		}
+	}
+	if a, ok := res.Resources[v1.ResourcePods]; ok {
+		a.Requests = *resource.NewQuantity(int64(res.Pods), resource.DecimalSI)
+	}
+	return &res
+}
+
+// Resources returns the allocated resources for each Node in the collection using the given Pods,
+// along with a cluster wide rollup summed across all Nodes.
+func (c *NodeCollection) Resources(pods *PodCollection) ([]*NodeResources, *NodeResources) {
+	var nodes []*NodeResources
+	var total NodeResources
+	for i := 0; i < len(c.Items); i++ {
+		node := Node{NodeAPIVersion, NodeKind, &c.Items[i]}
+		res := node.Resources(pods)
+		nodes = append(nodes, res)
+		total.add(res)
+	}
+	return nodes, &total
+}
+
+// podRequestsAndLimits returns the effective requests and limits for a Pod.
+// Init containers run sequentially, so the max of any init container and the sum of all app containers is used.
+func podRequestsAndLimits(pod *v1.Pod) (reqs, limits v1.ResourceList) {
+	reqs, limits = v1.ResourceList{}, v1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResourceList(reqs, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResourceList(reqs, container.Resources.Requests)
+		maxResourceList(limits, container.Resources.Limits)
+	}
+	return
+}
+
+func addResourceList(list, add v1.ResourceList) {
+	for name, q := range add {
+		if v, ok := list[name]; ok {
+			v.Add(q)
+			list[name] = v
+		} else {
+			list[name] = q.DeepCopy()
+		}
+	}
+}
+
+func maxResourceList(list, other v1.ResourceList) {
+	for name, q := range other {
+		if v, ok := list[name]; !ok || q.Cmp(v) > 0 {
+			list[name] = q.DeepCopy()
+		}
+	}
+}
+
+func percentOf(q, total resource.Quantity) float64 {
+	if total.IsZero() {
+		return 0
+	}
+	return float64(q.MilliValue()) / float64(total.MilliValue()) * 100
+}
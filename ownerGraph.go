@@ -0,0 +1,272 @@
+package ak8s
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// OwnerNode is a resource in an OwnerGraph linked to its owners and children by ownerReferences.
+// Ready summarizes the ready replicas of workloads or the ready containers of Pods, eg. 2/3.
+type OwnerNode struct {
+	Kind      string      `json:"kind"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	UID       types.UID   `json:"uid"`
+	Ready     string      `json:"ready"`
+	Status    string      `json:"status,omitempty"`
+	Created   metav1.Time `json:"created"`
+
+	ownerRefs []metav1.OwnerReference
+	owners    []*OwnerNode
+	children  []*OwnerNode
+}
+
+// OwnerGraph links Deployments to ReplicaSets to Pods and DaemonSets to Pods by ownerReferences.
+type OwnerGraph struct {
+	nodes map[types.UID]*OwnerNode
+}
+
+// Owners returns the owners of the node found in the graph.
+func (n *OwnerNode) Owners() []*OwnerNode {
+	return n.owners
+}
+
+// Children returns the resources owned by the node, sorted by kind and name.
+func (n *OwnerNode) Children() []*OwnerNode {
+	return n.children
+}
+
+// String returns the node as Kind/Name.
+func (n *OwnerNode) String() string {
+	return n.Kind + `/` + n.Name
+}
+
+// GetOwnerGraph returns an OwnerGraph built from all Deployments, DaemonSets, ReplicaSets and Pods
+// for the current namespace set on the client or across all namespaces if not set.
+func (c *Client) GetOwnerGraph() (*OwnerGraph, error) {
+	deps, err := c.GetAllDeployments()
+	if err != nil {
+		return &OwnerGraph{}, err
+	}
+	dss, err := c.GetAllDaemonSets()
+	if err != nil {
+		return &OwnerGraph{}, err
+	}
+	rss, err := c.GetAllReplicaSets()
+	if err != nil {
+		return &OwnerGraph{}, err
+	}
+	pods, err := c.GetAllPods()
+	if err != nil {
+		return &OwnerGraph{}, err
+	}
+	return NewOwnerGraph(deps, dss, rss, pods), nil
+}
+
+// NewOwnerGraph returns an OwnerGraph built from the given collections, any of which may be nil.
+func NewOwnerGraph(deps *DeployomentCollection, dss *DaemonSetCollection, rss *ReplicaSetCollection, pods *PodCollection) *OwnerGraph {
+	g := OwnerGraph{
+		nodes: make(map[types.UID]*OwnerNode),
+	}
+	if deps != nil && deps.DeploymentList != nil {
+		for _, item := range deps.Items {
+			desired := int32(1)
+			if item.Spec.Replicas != nil {
+				desired = *item.Spec.Replicas
+			}
+			dep := Deployment{DeploymentAPIVersion, DeploymentKind, &item}
+			g.add(DeploymentKind, &item.ObjectMeta, fmt.Sprintf("%d/%d", item.Status.ReadyReplicas, desired), dep.RolloutStatus().State)
+		}
+	}
+	if dss != nil && dss.DaemonSetList != nil {
+		for _, item := range dss.Items {
+			ds := DaemonSet{DaemonSetAPIVersion, DaemonSetKind, &item}
+			g.add(DaemonSetKind, &item.ObjectMeta, fmt.Sprintf("%d/%d", item.Status.NumberReady, item.Status.DesiredNumberScheduled), ds.RolloutStatus().State)
+		}
+	}
+	if rss != nil && rss.ReplicaSetList != nil {
+		for _, item := range rss.Items {
+			desired := int32(1)
+			if item.Spec.Replicas != nil {
+				desired = *item.Spec.Replicas
+			}
+			g.add(ReplicaSetKind, &item.ObjectMeta, fmt.Sprintf("%d/%d", item.Status.ReadyReplicas, desired), "")
+		}
+	}
+	if pods != nil && pods.PodList != nil {
+		for _, item := range pods.Items {
+			pod := Pod{PodAPIVersion, PodKind, &item}
+			g.add(PodKind, &item.ObjectMeta, pod.Ready(), pod.DisplayStatus())
+		}
+	}
+	for _, node := range g.nodes {
+		for _, ref := range node.ownerRefs {
+			if owner, ok := g.nodes[ref.UID]; ok {
+				node.owners = append(node.owners, owner)
+				owner.children = append(owner.children, node)
+			}
+		}
+	}
+	for _, node := range g.nodes {
+		sortOwnerNodes(node.owners)
+		sortOwnerNodes(node.children)
+	}
+	return &g
+}
+
+func (g *OwnerGraph) add(kind string, meta *metav1.ObjectMeta, ready, status string) {
+	g.nodes[meta.UID] = &OwnerNode{
+		Kind:      kind,
+		Namespace: meta.Namespace,
+		Name:      meta.Name,
+		UID:       meta.UID,
+		Ready:     ready,
+		Status:    status,
+		Created:   meta.CreationTimestamp,
+		ownerRefs: meta.OwnerReferences,
+	}
+}
+
+// Len returns the number of nodes in the graph.
+func (g *OwnerGraph) Len() int {
+	return len(g.nodes)
+}
+
+// Node returns the node with the given UID, or nil if not found.
+func (g *OwnerGraph) Node(uid types.UID) *OwnerNode {
+	return g.nodes[uid]
+}
+
+// Find returns the node of the given kind, namespace and name, or nil if not found.
+func (g *OwnerGraph) Find(kind, namespace, name string) *OwnerNode {
+	for _, node := range g.nodes {
+		if node.Kind == kind && node.Namespace == namespace && node.Name == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// Roots returns the nodes without an owner in the graph, sorted by namespace, kind and name.
+func (g *OwnerGraph) Roots() []*OwnerNode {
+	var roots []*OwnerNode
+	for _, node := range g.nodes {
+		if len(node.owners) < 1 {
+			roots = append(roots, node)
+		}
+	}
+	sortOwnerNodes(roots)
+	return roots
+}
+
+// Owners returns the owners of the Pod found in the graph.
+func (r *Pod) Owners(g *OwnerGraph) []*OwnerNode {
+	if node := g.Node(r.UID); node != nil {
+		return node.Owners()
+	}
+	return nil
+}
+
+// Owners returns the owners of the ReplicaSet found in the graph.
+func (r *ReplicaSet) Owners(g *OwnerGraph) []*OwnerNode {
+	if node := g.Node(r.UID); node != nil {
+		return node.Owners()
+	}
+	return nil
+}
+
+// Children returns the Pods owned by the ReplicaSet found in the graph.
+func (r *ReplicaSet) Children(g *OwnerGraph) []*OwnerNode {
+	if node := g.Node(r.UID); node != nil {
+		return node.Children()
+	}
+	return nil
+}
+
+// Children returns the ReplicaSets owned by the Deployment found in the graph.
+func (r *Deployment) Children(g *OwnerGraph) []*OwnerNode {
+	if node := g.Node(r.UID); node != nil {
+		return node.Children()
+	}
+	return nil
+}
+
+// Children returns the Pods owned by the DaemonSet found in the graph.
+func (r *DaemonSet) Children(g *OwnerGraph) []*OwnerNode {
+	if node := g.Node(r.UID); node != nil {
+		return node.Children()
+	}
+	return nil
+}
+
+// Tree writes the given nodes and their descendants to w as a tree, similar to kubectl tree.
+// If no nodes are given, all root nodes of the graph are written.
+func (g *OwnerGraph) Tree(w io.Writer, nodes ...*OwnerNode) error {
+	if len(nodes) < 1 {
+		nodes = g.Roots()
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tREADY\tSTATUS\tAGE")
+	now := time.Now()
+	var write func(node *OwnerNode, prefix, branch string)
+	write = func(node *OwnerNode, prefix, branch string) {
+		age := `<unknown>`
+		if !node.Created.IsZero() {
+			age = duration.HumanDuration(now.Sub(node.Created.Time))
+		}
+		fmt.Fprintf(tw, "%v\t%v%v%v\t%v\t%v\t%v\n", node.Namespace, prefix, branch, node, node.Ready, node.Status, age)
+		switch branch {
+		case `├─`:
+			prefix += `│ `
+		case `└─`:
+			prefix += `  `
+		}
+		for i, child := range node.children {
+			if i == len(node.children)-1 {
+				write(child, prefix, `└─`)
+			} else {
+				write(child, prefix, `├─`)
+			}
+		}
+	}
+	for _, node := range nodes {
+		write(node, "", "")
+	}
+	return tw.Flush()
+}
+
+func sortOwnerNodes(nodes []*OwnerNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		switch {
+		case a.Namespace != b.Namespace:
+			return a.Namespace < b.Namespace
+		case a.Kind != b.Kind:
+			return ownerKindOrder(a.Kind) < ownerKindOrder(b.Kind)
+		default:
+			return strings.Compare(a.Name, b.Name) < 0
+		}
+	})
+}
+
+// ownerKindOrder orders workloads before the resources they own.
+func ownerKindOrder(kind string) int {
+	switch kind {
+	case DeploymentKind:
+		return 0
+	case DaemonSetKind:
+		return 1
+	case ReplicaSetKind:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,310 @@
+package ak8s
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// CopyOptions contains the Options used when copying files to and from a Pod container.
+// If Container is empty, the first container of the Pod is used.
+// Progress, if set, is called as each file is copied with the bytes written so far and the file size.
+type CopyOptions struct {
+	Container string
+	Progress  func(name string, written, total int64)
+}
+
+// CopyToPod copies the localPath file or directory into the given pod at remotePath, preserving file modes.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) CopyToPod(localPath, pod, remotePath string, opts *CopyOptions) error {
+	p, err := c.GetPod(pod)
+	if err != nil {
+		return err
+	}
+	return p.CopyTo(c, localPath, remotePath, opts)
+}
+
+// CopyFromPod copies the remotePath file or directory from the given pod to localPath, preserving file modes.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) CopyFromPod(pod, remotePath, localPath string, opts *CopyOptions) error {
+	p, err := c.GetPod(pod)
+	if err != nil {
+		return err
+	}
+	return p.CopyFrom(c, remotePath, localPath, opts)
+}
+
+// CopyTo copies the localPath file or directory into the Pod at remotePath.
+func (r *Pod) CopyTo(c *Client, localPath, remotePath string, opts *CopyOptions) error {
+	if c.DryRun() {
+		return fmt.Errorf("copy is not supported with dry-run set on the client")
+	}
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	if _, err := os.Lstat(localPath); err != nil {
+		return err
+	}
+	remotePath = path.Clean(remotePath)
+	if remotePath == "/" || remotePath == "." {
+		return fmt.Errorf("invalid remote path: %v", remotePath)
+	}
+	pr, pw := io.Pipe()
+	tarErr := make(chan error, 1)
+	go func() {
+		err := writeTar(pw, localPath, path.Base(remotePath), opts.Progress)
+		pw.CloseWithError(err)
+		tarErr <- err
+	}()
+	var stderr bytes.Buffer
+	cmd := []string{"tar", "-xmf", "-", "-C", path.Dir(remotePath)}
+	err := r.Exec(c, opts.Container, cmd, &ExecOptions{
+		Stdin:  pr,
+		Stdout: &bytes.Buffer{},
+		Stderr: &stderr,
+	})
+	pr.Close()
+	tErr := <-tarErr
+	if err != nil {
+		return fmt.Errorf("%v: %v", err, strings.TrimSpace(stderr.String()))
+	}
+	return tErr
+}
+
+// CopyFrom copies the remotePath file or directory from the Pod to localPath.
+// Entries that would be extracted outside of localPath, including through symlinks, are rejected.
+func (r *Pod) CopyFrom(c *Client, remotePath, localPath string, opts *CopyOptions) error {
+	if c.DryRun() {
+		return fmt.Errorf("copy is not supported with dry-run set on the client")
+	}
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	remotePath = path.Clean(remotePath)
+	if remotePath == "/" || remotePath == "." {
+		return fmt.Errorf("invalid remote path: %v", remotePath)
+	}
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	execErr := make(chan error, 1)
+	go func() {
+		cmd := []string{"tar", "cf", "-", "-C", path.Dir(remotePath), path.Base(remotePath)}
+		err := r.Exec(c, opts.Container, cmd, &ExecOptions{
+			Stdout: pw,
+			Stderr: &stderr,
+		})
+		if err != nil {
+			err = fmt.Errorf("%v: %v", err, strings.TrimSpace(stderr.String()))
+		}
+		pw.CloseWithError(err)
+		execErr <- err
+	}()
+	err := readTar(pr, path.Base(remotePath), localPath, opts.Progress)
+	pr.Close()
+	eErr := <-execErr
+	if err != nil {
+		return err
+	}
+	return eErr
+}
+
+// writeTar writes src as a tar archive to w, naming entries under prefix.
+func writeTar(w io.Writer, src, prefix string, progress func(string, int64, int64)) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		name := path.Join(prefix, filepath.ToSlash(rel))
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, &progressReader{f, name, 0, fi.Size(), progress})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// readTar extracts the tar archive from r into dest, stripping prefix from entry names.
+// Directories are created writable and their modes are applied once the archive has been extracted.
+func readTar(r io.Reader, prefix, dest string, progress func(string, int64, int64)) error {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return err
+	}
+	type dirMode struct {
+		path string
+		mode os.FileMode
+	}
+	var dirs []dirMode
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			for i := len(dirs) - 1; i >= 0; i-- {
+				if err := os.Chmod(dirs[i].path, dirs[i].mode); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid tar entry: %v", hdr.Name)
+		}
+		rel := name
+		switch {
+		case name == prefix:
+			rel = "."
+		case strings.HasPrefix(name, prefix+"/"):
+			rel = strings.TrimPrefix(name, prefix+"/")
+		}
+		target := filepath.Join(dest, filepath.FromSlash(rel))
+		if !withinDir(dest, target) {
+			return fmt.Errorf("tar entry %v is outside of %v", hdr.Name, dest)
+		}
+		if err := checkNoSymlinks(dest, filepath.Dir(target)); err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode.Perm()|0700); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, mode.Perm()|0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, dirMode{target, mode.Perm()})
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if fi, err := os.Lstat(target); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, &progressReader{tr, rel, 0, hdr.Size, progress})
+			f.Close()
+			if err != nil {
+				return err
+			}
+			if err := os.Chmod(target, mode.Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := hdr.Linkname
+			if filepath.IsAbs(link) || !withinDir(dest, filepath.Join(filepath.Dir(target), link)) {
+				return fmt.Errorf("symlink %v -> %v points outside of %v", hdr.Name, hdr.Linkname, dest)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		default:
+			// Skip hard links, devices and other special files.
+		}
+	}
+}
+
+// withinDir returns true if target is dir or is located beneath dir.
+func withinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// checkNoSymlinks ensures no existing path element between base and dir is a symlink,
+// preventing extracted files from being written through a link outside of base.
+func checkNoSymlinks(base, dir string) error {
+	if !withinDir(base, dir) {
+		return nil
+	}
+	rel, err := filepath.Rel(base, dir)
+	if err != nil {
+		return err
+	}
+	current := base
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "." || part == "" {
+			continue
+		}
+		current = filepath.Join(current, part)
+		fi, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("refusing to extract through symlink %v", current)
+		}
+	}
+	return nil
+}
+
+// progressReader reports read progress for a single file.
+type progressReader struct {
+	r        io.Reader
+	name     string
+	read     int64
+	total    int64
+	progress func(string, int64, int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	if p.progress != nil && (n > 0 || err == io.EOF) {
+		p.progress(p.name, p.read, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,190 @@
+package ak8s
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// maxConcurrentExec limits the number of concurrent exec sessions run across a PodCollection.
+const maxConcurrentExec = 10
+
+// ExecOptions contains the Options used when executing a command within a Pod container.
+// When TTY is set, Stderr is merged into Stdout by the remote terminal.
+type ExecOptions struct {
+	Stdin             io.Reader
+	Stdout            io.Writer
+	Stderr            io.Writer
+	TTY               bool
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// ExecResult contains the output of a command executed within a Pod container.
+type ExecResult struct {
+	Namespace string
+	Pod       string
+	Container string
+	Stdout    string
+	Stderr    string
+	Err       error
+}
+
+// TerminalSizeQueue is a remotecommand.TerminalSizeQueue used to resize a TTY during Exec.
+type TerminalSizeQueue struct {
+	sizes   chan remotecommand.TerminalSize
+	mu      sync.Mutex
+	stopped bool
+}
+
+// NewTerminalSizeQueue returns a new TerminalSizeQueue.
+func NewTerminalSizeQueue() *TerminalSizeQueue {
+	return &TerminalSizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+	}
+}
+
+// Resize queues a new terminal size, replacing any size not yet sent. Resize does nothing once the queue is stopped.
+func (q *TerminalSizeQueue) Resize(width, height uint16) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return
+	}
+	size := remotecommand.TerminalSize{Width: width, Height: height}
+	for {
+		select {
+		case q.sizes <- size:
+			return
+		default:
+			select {
+			case <-q.sizes:
+			default:
+			}
+		}
+	}
+}
+
+// Next implements remotecommand.TerminalSizeQueue. It returns nil once the queue is stopped.
+func (q *TerminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
+
+// Stop stops the queue, ending any further resizing.
+func (q *TerminalSizeQueue) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.stopped {
+		q.stopped = true
+		close(q.sizes)
+	}
+}
+
+// Exec executes cmd within the given pod container, streaming using the given ExecOptions.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) Exec(pod, container string, cmd []string, opts *ExecOptions) error {
+	ns := c.NS
+	if ns == "" {
+		ns = `default`
+	}
+	return c.exec(ns, pod, container, cmd, opts)
+}
+
+func (c *Client) exec(ns, pod, container string, cmd []string, opts *ExecOptions) error {
+	if len(cmd) < 1 {
+		return fmt.Errorf("no command specified")
+	}
+	if c.DryRun() {
+		return fmt.Errorf("exec is not supported with dry-run set on the client")
+	}
+	if c.Config == nil {
+		return fmt.Errorf("no rest config found for client")
+	}
+	if opts == nil {
+		opts = &ExecOptions{}
+	}
+	req := c.CS.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(pod).
+		SubResource("exec")
+	req.VersionedParams(&v1.PodExecOptions{
+		Container: container,
+		Command:   cmd,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil && !opts.TTY,
+		TTY:       opts.TTY,
+	}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(c.Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	streamOpts := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizeQueue,
+	}
+	if !opts.TTY {
+		streamOpts.Stderr = opts.Stderr
+	}
+	return executor.Stream(streamOpts)
+}
+
+// Exec executes cmd within the given container of the Pod.
+// If container is empty, the first container of the Pod is used.
+func (r *Pod) Exec(c *Client, container string, cmd []string, opts *ExecOptions) error {
+	if r.Pod == nil {
+		return fmt.Errorf("no pod specified")
+	}
+	if container == "" && len(r.Spec.Containers) > 0 {
+		container = r.Spec.Containers[0].Name
+	}
+	return c.exec(r.Namespace, r.Name, container, cmd, opts)
+}
+
+// Exec concurrently executes cmd within the given container of every Pod in the collection and collects the outputs.
+// If container is empty, the first container of each Pod is used.
+// Results are returned in the same order as the collection items.
+func (c *PodCollection) Exec(client *Client, container string, cmd []string) []ExecResult {
+	results := make([]ExecResult, len(c.Items))
+	sem := make(chan struct{}, maxConcurrentExec)
+	var wg sync.WaitGroup
+	for i := 0; i < len(c.Items); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			pod := Pod{PodAPIVersion, PodKind, &c.Items[i]}
+			cont := container
+			if cont == "" && len(pod.Spec.Containers) > 0 {
+				cont = pod.Spec.Containers[0].Name
+			}
+			var stdout, stderr bytes.Buffer
+			err := pod.Exec(client, cont, cmd, &ExecOptions{
+				Stdout: &stdout,
+				Stderr: &stderr,
+			})
+			results[i] = ExecResult{
+				Namespace: pod.Namespace,
+				Pod:       pod.Name,
+				Container: cont,
+				Stdout:    stdout.String(),
+				Stderr:    stderr.String(),
+				Err:       err,
+			}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
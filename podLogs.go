@@ -0,0 +1,148 @@
+package ak8s
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LogOptions contains the Options used when retrieving Pod logs.
+type LogOptions struct {
+	Container  string
+	Previous   bool
+	Follow     bool
+	Timestamps bool
+	Since      time.Duration
+	SinceTime  time.Time
+	TailLines  int64
+	LimitBytes int64
+}
+
+// PodLogOptions returns the v1.PodLogOptions for the given LogOptions.
+func (o *LogOptions) PodLogOptions() *v1.PodLogOptions {
+	opts := v1.PodLogOptions{}
+	if o == nil {
+		return &opts
+	}
+	opts.Container = o.Container
+	opts.Previous = o.Previous
+	opts.Follow = o.Follow
+	opts.Timestamps = o.Timestamps
+	switch {
+	case o.Since > 0:
+		secs := int64(o.Since.Round(time.Second).Seconds())
+		if secs < 1 {
+			secs = 1
+		}
+		opts.SinceSeconds = &secs
+	case !o.SinceTime.IsZero():
+		t := metav1.NewTime(o.SinceTime)
+		opts.SinceTime = &t
+	}
+	if o.TailLines > 0 {
+		tail := o.TailLines
+		opts.TailLines = &tail
+	}
+	if o.LimitBytes > 0 {
+		limit := o.LimitBytes
+		opts.LimitBytes = &limit
+	}
+	return &opts
+}
+
+// PodLogs returns a log stream for the given pod name.
+// If the namespace is not set on the client, the "default" namespace is used.
+// The caller is responsible for closing the returned stream.
+func (c *Client) PodLogs(name string, opts *LogOptions) (io.ReadCloser, error) {
+	ns := c.NS
+	if ns == "" {
+		ns = `default`
+	}
+	return c.podLogs(ns, name, opts)
+}
+
+func (c *Client) podLogs(ns, name string, opts *LogOptions) (io.ReadCloser, error) {
+	return c.CS.CoreV1().Pods(ns).GetLogs(name, opts.PodLogOptions()).Stream()
+}
+
+// Logs returns a log stream for the Pod.
+// The caller is responsible for closing the returned stream.
+func (r *Pod) Logs(c *Client, opts *LogOptions) (io.ReadCloser, error) {
+	if r.Pod == nil {
+		return nil, fmt.Errorf("no pod specified")
+	}
+	return c.podLogs(r.Namespace, r.Name, opts)
+}
+
+// Logs writes the logs for all Pods in the collection to w, prefixing each line with [pod/container].
+// If no container is set in opts, logs are retrieved for every container in each Pod.
+// When opts.Follow is set, all streams are followed concurrently until they are closed.
+func (c *PodCollection) Logs(client *Client, opts *LogOptions, w io.Writer) error {
+	type logTarget struct {
+		ns        string
+		pod       string
+		container string
+	}
+	var targets []logTarget
+	for _, pod := range c.Items {
+		if opts != nil && opts.Container != "" {
+			targets = append(targets, logTarget{pod.Namespace, pod.Name, opts.Container})
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			targets = append(targets, logTarget{pod.Namespace, pod.Name, container.Name})
+		}
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errd string
+	streamLogs := func(t logTarget) {
+		o := LogOptions{}
+		if opts != nil {
+			o = *opts
+		}
+		o.Container = t.container
+		stream, err := client.podLogs(t.ns, t.pod, &o)
+		if err != nil {
+			mu.Lock()
+			errd += (fmt.Sprintf("%v/%v: %v", t.pod, t.container, err) + fmt.Sprintf("\n"))
+			mu.Unlock()
+			return
+		}
+		defer stream.Close()
+		prefix := fmt.Sprintf("[%v/%v] ", t.pod, t.container)
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			mu.Lock()
+			fmt.Fprintln(w, prefix+scanner.Text())
+			mu.Unlock()
+		}
+		if err := scanner.Err(); err != nil {
+			mu.Lock()
+			errd += (fmt.Sprintf("%v/%v: %v", t.pod, t.container, err) + fmt.Sprintf("\n"))
+			mu.Unlock()
+		}
+	}
+	for _, t := range targets {
+		if opts != nil && opts.Follow {
+			wg.Add(1)
+			go func(t logTarget) {
+				defer wg.Done()
+				streamLogs(t)
+			}(t)
+			continue
+		}
+		streamLogs(t)
+	}
+	wg.Wait()
+	if errd != "" {
+		return fmt.Errorf("%v", errd)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package ak8s
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+)
+
+// Pod Status Constants:
+const (
+	PodStatusTerminating = `Terminating`
+	PodStatusUnknown     = `Unknown`
+	PodStatusCompleted   = `Completed`
+	PodStatusRunning     = `Running`
+	PodStatusNotReady    = `NotReady`
+	podReasonNodeLost    = `NodeLost`
+	podReasonInitialized = `PodInitializing`
+)
+
+// DisplayStatus returns the status of the Pod as displayed by kubectl, eg. Running, CrashLoopBackOff, Init:0/1.
+func (r *Pod) DisplayStatus() string {
+	if r.Pod == nil {
+		return ""
+	}
+	reason := string(r.Pod.Status.Phase)
+	if r.Pod.Status.Reason != "" {
+		reason = r.Pod.Status.Reason
+	}
+	initializing := false
+	for i, container := range r.Pod.Status.InitContainerStatuses {
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case container.State.Terminated != nil:
+			switch {
+			case container.State.Terminated.Reason != "":
+				reason = `Init:` + container.State.Terminated.Reason
+			case container.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Init:Signal:%d", container.State.Terminated.Signal)
+			default:
+				reason = fmt.Sprintf("Init:ExitCode:%d", container.State.Terminated.ExitCode)
+			}
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != podReasonInitialized:
+			reason = `Init:` + container.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(r.Pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+	if !initializing {
+		hasRunning := false
+		for i := len(r.Pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := r.Pod.Status.ContainerStatuses[i]
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				reason = container.State.Waiting.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+				reason = container.State.Terminated.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Signal:%d", container.State.Terminated.Signal)
+			case container.State.Terminated != nil:
+				reason = fmt.Sprintf("ExitCode:%d", container.State.Terminated.ExitCode)
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+			}
+		}
+		if reason == PodStatusCompleted && hasRunning {
+			if r.IsReady() {
+				reason = PodStatusRunning
+			} else {
+				reason = PodStatusNotReady
+			}
+		}
+	}
+	switch {
+	case r.Pod.DeletionTimestamp != nil && r.Pod.Status.Reason == podReasonNodeLost:
+		reason = PodStatusUnknown
+	case r.Pod.DeletionTimestamp != nil:
+		reason = PodStatusTerminating
+	}
+	return reason
+}
+
+// IsReady returns true if the Pod has a Ready condition set to True.
+func (r *Pod) IsReady() bool {
+	if r.Pod == nil {
+		return false
+	}
+	for _, cond := range r.Pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ReadyCount returns the number of ready containers and the total number of containers for the Pod.
+func (r *Pod) ReadyCount() (ready, total int) {
+	if r.Pod == nil {
+		return
+	}
+	total = len(r.Pod.Spec.Containers)
+	for _, container := range r.Pod.Status.ContainerStatuses {
+		if container.Ready {
+			ready++
+		}
+	}
+	return
+}
+
+// Ready returns the ready containers for the Pod as displayed by kubectl, eg. 2/3.
+func (r *Pod) Ready() string {
+	ready, total := r.ReadyCount()
+	return fmt.Sprintf("%d/%d", ready, total)
+}
+
+// Restarts returns the total restart count across all containers for the Pod.
+// Init container restarts are included only while the Pod is still initializing, matching kubectl.
+func (r *Pod) Restarts() int32 {
+	if r.Pod == nil {
+		return 0
+	}
+	var restarts int32
+	initializing := false
+	for _, container := range r.Pod.Status.InitContainerStatuses {
+		restarts += container.RestartCount
+		if container.State.Terminated == nil || container.State.Terminated.ExitCode != 0 {
+			initializing = true
+			break
+		}
+	}
+	if initializing {
+		return restarts
+	}
+	restarts = 0
+	for _, container := range r.Pod.Status.ContainerStatuses {
+		restarts += container.RestartCount
+	}
+	return restarts
+}
+
+// LastTerminationReason returns the most recent termination reason found across the Pod containers, eg. OOMKilled.
+// An empty string is returned if no container has terminated.
+func (r *Pod) LastTerminationReason() string {
+	if r.Pod == nil {
+		return ""
+	}
+	var reason string
+	var last *v1.ContainerStateTerminated
+	statuses := append([]v1.ContainerStatus{}, r.Pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, r.Pod.Status.ContainerStatuses...)
+	for _, container := range statuses {
+		for _, term := range []*v1.ContainerStateTerminated{container.State.Terminated, container.LastTerminationState.Terminated} {
+			if term == nil {
+				continue
+			}
+			if last == nil || term.FinishedAt.After(last.FinishedAt.Time) {
+				last = term
+				reason = term.Reason
+				if reason == "" {
+					reason = fmt.Sprintf("ExitCode:%d", term.ExitCode)
+				}
+			}
+		}
+	}
+	return reason
+}
+
+// StatusCounts returns the number of Pods grouped by their status.
+func (c *PodCollection) StatusCounts() map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < len(c.Items); i++ {
+		pod := Pod{PodAPIVersion, PodKind, &c.Items[i]}
+		counts[pod.DisplayStatus()]++
+	}
+	return counts
+}
+
+// ByStatus returns the Pods matching any of the given statuses.
+func (c *PodCollection) ByStatus(status ...string) *PodCollection {
+	var list v1.PodList
+	var matches []v1.Pod
+	for i := 0; i < len(c.Items); i++ {
+		pod := Pod{PodAPIVersion, PodKind, &c.Items[i]}
+		s := pod.DisplayStatus()
+		for _, st := range status {
+			if s == st {
+				matches = append(matches, c.Items[i])
+				break
+			}
+		}
+	}
+	list.Items = matches
+	return &PodCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}
+}
+
+// ReadyCount returns the number of ready Pods and the total number of Pods in the collection.
+func (c *PodCollection) ReadyCount() (ready, total int) {
+	total = len(c.Items)
+	for i := 0; i < len(c.Items); i++ {
+		pod := Pod{PodAPIVersion, PodKind, &c.Items[i]}
+		if pod.IsReady() {
+			ready++
+		}
+	}
+	return
+}
+
+// TotalRestarts returns the sum of restarts across all Pods in the collection.
+func (c *PodCollection) TotalRestarts() int32 {
+	var restarts int32
+	for i := 0; i < len(c.Items); i++ {
+		pod := Pod{PodAPIVersion, PodKind, &c.Items[i]}
+		restarts += pod.Restarts()
+	}
+	return restarts
+}
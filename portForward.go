@@ -0,0 +1,178 @@
+package ak8s
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForwardOptions contains the Options used when port-forwarding.
+// Ports are given as local:remote, :remote (random local port) or port (same local and remote port).
+// Addresses defaults to localhost if not set. Closing StopChan stops forwarding, ReadyChan is closed once forwarding is ready.
+type PortForwardOptions struct {
+	Addresses []string
+	Ports     []string
+	StopChan  <-chan struct{}
+	ReadyChan chan struct{}
+	Out       io.Writer
+	ErrOut    io.Writer
+}
+
+// PortForwarder forwards local ports to a Pod.
+// Call ForwardPorts to begin forwarding, which blocks until StopChan is closed.
+type PortForwarder struct {
+	Namespace string
+	Pod       string
+	*portforward.PortForwarder
+}
+
+// PortForwardPod returns a PortForwarder for the given pod name.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) PortForwardPod(name string, opts *PortForwardOptions) (*PortForwarder, error) {
+	pod, err := c.GetPod(name)
+	if err != nil {
+		return &PortForwarder{}, err
+	}
+	return pod.PortForward(c, opts)
+}
+
+// PortForwardService returns a PortForwarder to a ready Pod backing the given service name.
+// Remote ports are given as service ports and are translated to the matching Pod target ports.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) PortForwardService(name string, opts *PortForwardOptions) (*PortForwarder, error) {
+	svc, err := c.GetService(name)
+	if err != nil {
+		return &PortForwarder{}, err
+	}
+	pod, err := c.serviceReadyPod(svc)
+	if err != nil {
+		return &PortForwarder{}, err
+	}
+	if opts == nil {
+		return &PortForwarder{}, fmt.Errorf("no ports specified")
+	}
+	var ports []string
+	for _, p := range opts.Ports {
+		port, err := translateServicePort(svc, pod, p)
+		if err != nil {
+			return &PortForwarder{}, err
+		}
+		ports = append(ports, port)
+	}
+	o := *opts
+	o.Ports = ports
+	return pod.PortForward(c, &o)
+}
+
+// PortForward returns a PortForwarder for the Pod.
+func (r *Pod) PortForward(c *Client, opts *PortForwardOptions) (*PortForwarder, error) {
+	if r.Pod == nil {
+		return &PortForwarder{}, fmt.Errorf("no pod specified")
+	}
+	if opts == nil || len(opts.Ports) < 1 {
+		return &PortForwarder{}, fmt.Errorf("no ports specified")
+	}
+	if c.Config == nil {
+		return &PortForwarder{}, fmt.Errorf("no rest config found for client")
+	}
+	if r.Pod.Status.Phase != v1.PodRunning {
+		return &PortForwarder{}, fmt.Errorf("unable to forward port, pod %v is not running: %v", r.Name, r.Pod.Status.Phase)
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(c.Config)
+	if err != nil {
+		return &PortForwarder{}, err
+	}
+	req := c.CS.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(r.Namespace).
+		Name(r.Name).
+		SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	addresses := opts.Addresses
+	if len(addresses) < 1 {
+		addresses = []string{`localhost`}
+	}
+	out, errOut := opts.Out, opts.ErrOut
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if errOut == nil {
+		errOut = ioutil.Discard
+	}
+	pf, err := portforward.NewOnAddresses(dialer, addresses, opts.Ports, opts.StopChan, opts.ReadyChan, out, errOut)
+	if err != nil {
+		return &PortForwarder{}, err
+	}
+	return &PortForwarder{
+		Namespace:     r.Namespace,
+		Pod:           r.Name,
+		PortForwarder: pf,
+	}, nil
+}
+
+// serviceReadyPod returns a running and ready Pod matching the Service selector.
+func (c *Client) serviceReadyPod(svc *Service) (*Pod, error) {
+	if len(svc.Spec.Selector) < 1 {
+		return &Pod{}, fmt.Errorf("service %v has no selector", svc.Name)
+	}
+	opts := c.Options[ListOption].(*ListAction).Get()
+	opts.LabelSelector = labels.SelectorFromSet(svc.Spec.Selector).String()
+	list, err := c.CS.CoreV1().Pods(svc.Namespace).List(opts)
+	if err != nil {
+		return &Pod{}, err
+	}
+	for i := 0; i < len(list.Items); i++ {
+		pod := Pod{PodAPIVersion, PodKind, &list.Items[i]}
+		if pod.DeletionTimestamp == nil && pod.Pod.Status.Phase == v1.PodRunning && pod.IsReady() {
+			return &pod, nil
+		}
+	}
+	return &Pod{}, fmt.Errorf("no ready pods found for service %v", svc.Name)
+}
+
+// translateServicePort translates a local:servicePort mapping into a local:targetPort mapping for the given Pod.
+func translateServicePort(svc *Service, pod *Pod, port string) (string, error) {
+	local, remote := port, port
+	if parts := strings.Split(port, ":"); len(parts) == 2 {
+		local, remote = parts[0], parts[1]
+	}
+	svcPort, err := strconv.Atoi(remote)
+	if err != nil {
+		return "", fmt.Errorf("invalid port %v: %v", port, err)
+	}
+	for _, sp := range svc.Spec.Ports {
+		if int(sp.Port) != svcPort {
+			continue
+		}
+		target, err := podTargetPort(pod, sp.TargetPort)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v:%d", local, target), nil
+	}
+	return "", fmt.Errorf("service %v does not have port %d", svc.Name, svcPort)
+}
+
+// podTargetPort resolves a service targetPort to a container port number for the given Pod.
+func podTargetPort(pod *Pod, target intstr.IntOrString) (int32, error) {
+	if target.Type == intstr.Int {
+		return target.IntVal, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, cp := range container.Ports {
+			if cp.Name == target.StrVal {
+				return cp.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("pod %v does not have a named port %v", pod.Name, target.StrVal)
+}
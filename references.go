@@ -0,0 +1,202 @@
+package ak8s
+
+import (
+	"sort"
+
+	"k8s.io/api/core/v1"
+)
+
+// Reference Constants:
+const (
+	RefViaVolume           = `volume`
+	RefViaProjectedVolume  = `projected`
+	RefViaEnvFrom          = `envFrom`
+	RefViaEnv              = `env`
+	RefViaImagePullSecrets = `imagePullSecrets`
+)
+
+// Reference describes a workload referencing a Secret or ConfigMap.
+// Via lists how the resource is referenced, eg. volume:certs, envFrom:app, env:app/DB_PASSWORD or imagePullSecrets.
+type Reference struct {
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Via       []string `json:"via"`
+}
+
+// ReferenceIndex is a reverse index of the Secrets and ConfigMaps referenced by workloads.
+type ReferenceIndex struct {
+	secrets    map[string][]Reference
+	configMaps map[string][]Reference
+}
+
+// podSpecRefs contains the Secret and ConfigMap names referenced by a PodSpec mapped to how they are referenced.
+type podSpecRefs struct {
+	secrets    map[string][]string
+	configMaps map[string][]string
+}
+
+// GetReferenceIndex returns a ReferenceIndex built from all Deployments, DaemonSets, ReplicaSets and Pods
+// for the current namespace set on the client or across all namespaces if not set.
+func (c *Client) GetReferenceIndex() (*ReferenceIndex, error) {
+	deps, err := c.GetAllDeployments()
+	if err != nil {
+		return &ReferenceIndex{}, err
+	}
+	dss, err := c.GetAllDaemonSets()
+	if err != nil {
+		return &ReferenceIndex{}, err
+	}
+	rss, err := c.GetAllReplicaSets()
+	if err != nil {
+		return &ReferenceIndex{}, err
+	}
+	pods, err := c.GetAllPods()
+	if err != nil {
+		return &ReferenceIndex{}, err
+	}
+	return NewReferenceIndex(deps, dss, rss, pods), nil
+}
+
+// NewReferenceIndex returns a ReferenceIndex built from the given collections, any of which may be nil.
+func NewReferenceIndex(deps *DeployomentCollection, dss *DaemonSetCollection, rss *ReplicaSetCollection, pods *PodCollection) *ReferenceIndex {
+	idx := ReferenceIndex{
+		secrets:    make(map[string][]Reference),
+		configMaps: make(map[string][]Reference),
+	}
+	if deps != nil && deps.DeploymentList != nil {
+		for _, item := range deps.Items {
+			idx.add(DeploymentKind, item.Namespace, item.Name, &item.Spec.Template.Spec)
+		}
+	}
+	if dss != nil && dss.DaemonSetList != nil {
+		for _, item := range dss.Items {
+			idx.add(DaemonSetKind, item.Namespace, item.Name, &item.Spec.Template.Spec)
+		}
+	}
+	if rss != nil && rss.ReplicaSetList != nil {
+		for _, item := range rss.Items {
+			idx.add(ReplicaSetKind, item.Namespace, item.Name, &item.Spec.Template.Spec)
+		}
+	}
+	if pods != nil && pods.PodList != nil {
+		for _, item := range pods.Items {
+			idx.add(PodKind, item.Namespace, item.Name, &item.Spec)
+		}
+	}
+	return &idx
+}
+
+func (i *ReferenceIndex) add(kind, namespace, name string, spec *v1.PodSpec) {
+	refs := podSpecReferences(spec)
+	for secret, via := range refs.secrets {
+		key := namespace + `/` + secret
+		i.secrets[key] = append(i.secrets[key], Reference{kind, namespace, name, via})
+	}
+	for cm, via := range refs.configMaps {
+		key := namespace + `/` + cm
+		i.configMaps[key] = append(i.configMaps[key], Reference{kind, namespace, name, via})
+	}
+}
+
+// SecretReferences returns the workloads referencing the given Secret.
+func (i *ReferenceIndex) SecretReferences(namespace, name string) []Reference {
+	return i.secrets[namespace+`/`+name]
+}
+
+// ConfigMapReferences returns the workloads referencing the given ConfigMap.
+func (i *ReferenceIndex) ConfigMapReferences(namespace, name string) []Reference {
+	return i.configMaps[namespace+`/`+name]
+}
+
+// ReferencedSecrets returns the sorted namespace/name of all referenced Secrets.
+func (i *ReferenceIndex) ReferencedSecrets() []string {
+	return sortedKeys(i.secrets)
+}
+
+// ReferencedConfigMaps returns the sorted namespace/name of all referenced ConfigMaps.
+func (i *ReferenceIndex) ReferencedConfigMaps() []string {
+	return sortedKeys(i.configMaps)
+}
+
+// References returns the workloads referencing the Secret.
+func (r *Secret) References(idx *ReferenceIndex) []Reference {
+	if r.Secret == nil {
+		return nil
+	}
+	return idx.SecretReferences(r.Namespace, r.Name)
+}
+
+// podSpecReferences returns the Secrets and ConfigMaps referenced by the PodSpec
+// through volumes, projected volumes, envFrom, env valueFrom and imagePullSecrets.
+func podSpecReferences(spec *v1.PodSpec) podSpecRefs {
+	refs := podSpecRefs{
+		secrets:    make(map[string][]string),
+		configMaps: make(map[string][]string),
+	}
+	for _, vol := range spec.Volumes {
+		switch {
+		case vol.Secret != nil:
+			refs.addSecret(vol.Secret.SecretName, RefViaVolume+`:`+vol.Name)
+		case vol.ConfigMap != nil:
+			refs.addConfigMap(vol.ConfigMap.Name, RefViaVolume+`:`+vol.Name)
+		case vol.Projected != nil:
+			for _, src := range vol.Projected.Sources {
+				if src.Secret != nil {
+					refs.addSecret(src.Secret.Name, RefViaProjectedVolume+`:`+vol.Name)
+				}
+				if src.ConfigMap != nil {
+					refs.addConfigMap(src.ConfigMap.Name, RefViaProjectedVolume+`:`+vol.Name)
+				}
+			}
+		}
+	}
+	containers := append([]v1.Container{}, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.EnvFrom {
+			if env.SecretRef != nil {
+				refs.addSecret(env.SecretRef.Name, RefViaEnvFrom+`:`+container.Name)
+			}
+			if env.ConfigMapRef != nil {
+				refs.addConfigMap(env.ConfigMapRef.Name, RefViaEnvFrom+`:`+container.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				refs.addSecret(env.ValueFrom.SecretKeyRef.Name, RefViaEnv+`:`+container.Name+`/`+env.Name)
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				refs.addConfigMap(env.ValueFrom.ConfigMapKeyRef.Name, RefViaEnv+`:`+container.Name+`/`+env.Name)
+			}
+		}
+	}
+	for _, ref := range spec.ImagePullSecrets {
+		refs.addSecret(ref.Name, RefViaImagePullSecrets)
+	}
+	return refs
+}
+
+func (r podSpecRefs) addSecret(name, via string) {
+	if name != "" {
+		r.secrets[name] = append(r.secrets[name], via)
+	}
+}
+
+func (r podSpecRefs) addConfigMap(name, via string) {
+	if name != "" {
+		r.configMaps[name] = append(r.configMaps[name], via)
+	}
+}
+
+func sortedKeys(m map[string][]Reference) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
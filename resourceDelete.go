@@ -0,0 +1,290 @@
+package ak8s
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeletePods deletes the given pods and returns the pods deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeletePods(names ...string) (*PodCollection, error) {
+	items, err := c.GetPods(names...)
+	if items.PodList == nil {
+		return &PodCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteNodes deletes the given nodes and returns the nodes deleted, or that would be deleted if dry-run is set on the client.
+func (c *Client) DeleteNodes(names ...string) (*NodeCollection, error) {
+	items, err := c.GetNodes(names...)
+	if items.NodeList == nil {
+		return &NodeCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteSecrets deletes the given secrets and returns the secrets deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteSecrets(names ...string) (*SecretCollection, error) {
+	items, err := c.GetSecrets(names...)
+	if items.SecretList == nil {
+		return &SecretCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteServices deletes the given services and returns the services deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteServices(names ...string) (*ServiceCollection, error) {
+	items, err := c.GetServices(names...)
+	if items.ServiceList == nil {
+		return &ServiceCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteDeployments deletes the given deployments and returns the deployments deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteDeployments(names ...string) (*DeployomentCollection, error) {
+	items, err := c.GetDeployments(names...)
+	if items.DeploymentList == nil {
+		return &DeployomentCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteDaemonSets deletes the given daemonsets and returns the daemonsets deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteDaemonSets(names ...string) (*DaemonSetCollection, error) {
+	items, err := c.GetDaemonSets(names...)
+	if items.DaemonSetList == nil {
+		return &DaemonSetCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteReplicaSets deletes the given replicasets and returns the replicasets deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteReplicaSets(names ...string) (*ReplicaSetCollection, error) {
+	items, err := c.GetReplicaSets(names...)
+	if items.ReplicaSetList == nil {
+		return &ReplicaSetCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// DeleteIngresses deletes the given ingresses and returns the ingresses deleted, or that would be deleted if dry-run is set on the client.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) DeleteIngresses(names ...string) (*IngressCollection, error) {
+	items, err := c.GetIngresses(names...)
+	if items.IngressObjectList == nil {
+		return &IngressCollection{}, err
+	}
+	deleted, delErr := items.Delete(c)
+	return deleted, joinErrors(err, delErr)
+}
+
+// Delete deletes all Pods in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Pods deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *PodCollection) Delete(client *Client) (*PodCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.CoreV1().Pods(namespace).Delete(name, opts)
+	})
+	list := v1.PodList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &PodCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all Nodes in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Nodes deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *NodeCollection) Delete(client *Client) (*NodeCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.CoreV1().Nodes().Delete(name, opts)
+	})
+	list := v1.NodeList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &NodeCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all Secrets in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Secrets deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *SecretCollection) Delete(client *Client) (*SecretCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.CoreV1().Secrets(namespace).Delete(name, opts)
+	})
+	list := v1.SecretList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &SecretCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all Services in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Services deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *ServiceCollection) Delete(client *Client) (*ServiceCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.CoreV1().Services(namespace).Delete(name, opts)
+	})
+	list := v1.ServiceList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &ServiceCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all Deployments in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Deployments deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *DeployomentCollection) Delete(client *Client) (*DeployomentCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.AppsV1().Deployments(namespace).Delete(name, opts)
+	})
+	list := appsv1.DeploymentList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &DeployomentCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all DaemonSets in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the DaemonSets deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *DaemonSetCollection) Delete(client *Client) (*DaemonSetCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.AppsV1().DaemonSets(namespace).Delete(name, opts)
+	})
+	list := appsv1.DaemonSetList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &DaemonSetCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all ReplicaSets in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the ReplicaSets deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *ReplicaSetCollection) Delete(client *Client) (*ReplicaSetCollection, error) {
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return client.CS.AppsV1().ReplicaSets(namespace).Delete(name, opts)
+	})
+	list := appsv1.ReplicaSetList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &ReplicaSetCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// Delete deletes all Ingresses in the collection, eg. the result of a Search, using the delete options set on the client
+// and returns the Ingresses deleted, or that would be deleted if dry-run is set. Errors are collected and returned together.
+func (c *IngressCollection) Delete(client *Client) (*IngressCollection, error) {
+	gvr, err := client.ingressResource()
+	if err != nil {
+		return &IngressCollection{}, err
+	}
+	dc, err := client.dynamicClient()
+	if err != nil {
+		return &IngressCollection{}, err
+	}
+	deleted, err := client.deleteEach(len(c.Items), func(i int) (string, string) {
+		return c.Items[i].Namespace, c.Items[i].Name
+	}, func(namespace, name string, opts *metav1.DeleteOptions) error {
+		return dc.Resource(gvr).Namespace(namespace).Delete(name, opts)
+	})
+	list := IngressObjectList{TypeMeta: c.TypeMeta}
+	for _, i := range deleted {
+		list.Items = append(list.Items, c.Items[i])
+	}
+	return &IngressCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, err
+}
+
+// deleteEach deletes count items, whose namespace and name are returned by item, using del with the delete options
+// set on the client. The indexes of the items deleted are returned, errors are collected per item and returned together.
+func (c *Client) deleteEach(count int, item func(int) (string, string), del func(namespace, name string, opts *metav1.DeleteOptions) error) ([]int, error) {
+	opts := c.Options[DeleteOption].(*DeleteAction).Get()
+	var deleted []int
+	var errd string
+	for i := 0; i < count; i++ {
+		namespace, name := item(i)
+		if err := del(namespace, name, &opts); err != nil {
+			errd += (fmt.Sprintf("%v: %v", name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		deleted = append(deleted, i)
+	}
+	if errd != "" {
+		return deleted, fmt.Errorf("%v", errd)
+	}
+	return deleted, nil
+}
+
+// joinErrors returns the non-nil errors joined into a single error, or nil if there are none.
+func joinErrors(errs ...error) error {
+	var errd string
+	for _, err := range errs {
+		if err != nil {
+			errd += (err.Error() + fmt.Sprintf("\n"))
+		}
+	}
+	if errd != "" {
+		return fmt.Errorf("%v", errd)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Rollout State Constants:
+const (
+	RolloutComplete    = `complete`
+	RolloutProgressing = `progressing`
+	RolloutPaused      = `paused`
+	RolloutFailed      = `failed`
+	RolloutUnknown     = `unknown`
+)
+
+// progressDeadlineExceededReason is the Progressing condition reason set once a Deployment exceeds its progress deadline.
+const progressDeadlineExceededReason = `ProgressDeadlineExceeded`
+
+// RolloutStatus is a single progress report for the rollout of a Deployment or DaemonSet, mirroring kubectl rollout status.
+// Desired is the desired replica count for Deployments or the desired number of scheduled Pods for DaemonSets.
+type RolloutStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Message   string `json:"message"`
+	Desired   int32  `json:"desired"`
+	Current   int32  `json:"current"`
+	Updated   int32  `json:"updated"`
+	Ready     int32  `json:"ready"`
+	Available int32  `json:"available"`
+}
+
+// Done returns true if the rollout is complete.
+func (s *RolloutStatus) Done() bool {
+	return s.State == RolloutComplete
+}
+
+// RolloutRevision describes a single revision in the rollout history of a Deployment or DaemonSet.
+// Source is the ReplicaSet or ControllerRevision holding the revision and Changes lists the image changes
+// from the previous revision.
+type RolloutRevision struct {
+	Revision    int64             `json:"revision"`
+	Source      string            `json:"source"`
+	ChangeCause string            `json:"changeCause,omitempty"`
+	Created     time.Time         `json:"created"`
+	Images      map[string]string `json:"images"`
+	Changes     []ImageChange     `json:"changes,omitempty"`
+	Current     bool              `json:"current"`
+}
+
+// ImageChange describes a container image change between two revisions.
+// From is empty for added containers and To is empty for removed containers.
+type ImageChange struct {
+	Container string `json:"container"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+// RolloutStatus returns the rollout status of the Deployment, interpreting its replica counts and Progressing condition.
+func (r *Deployment) RolloutStatus() *RolloutStatus {
+	if r.Deployment == nil {
+		return &RolloutStatus{}
+	}
+	s := RolloutStatus{
+		Kind:      DeploymentKind,
+		Namespace: r.Namespace,
+		Name:      r.Name,
+		Desired:   1,
+		Current:   r.Status.Replicas,
+		Updated:   r.Status.UpdatedReplicas,
+		Ready:     r.Status.ReadyReplicas,
+		Available: r.Status.AvailableReplicas,
+	}
+	if r.Spec.Replicas != nil {
+		s.Desired = *r.Spec.Replicas
+	}
+	switch {
+	case r.Generation > r.Status.ObservedGeneration:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for deployment %q spec update to be observed", r.Name)
+	case r.Spec.Paused:
+		s.State, s.Message = RolloutPaused, fmt.Sprintf("deployment %q is paused", r.Name)
+	case deploymentDeadlineExceeded(r.Deployment):
+		s.State, s.Message = RolloutFailed, fmt.Sprintf("deployment %q exceeded its progress deadline", r.Name)
+	case s.Updated < s.Desired:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated", r.Name, s.Updated, s.Desired)
+	case s.Current > s.Updated:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for deployment %q rollout to finish: %d old replicas are pending termination", r.Name, s.Current-s.Updated)
+	case s.Available < s.Updated:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for deployment %q rollout to finish: %d of %d updated replicas are available", r.Name, s.Available, s.Updated)
+	default:
+		s.State, s.Message = RolloutComplete, fmt.Sprintf("deployment %q successfully rolled out", r.Name)
+	}
+	return &s
+}
+
+func deploymentDeadlineExceeded(dep *appsv1.Deployment) bool {
+	for _, cond := range dep.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Reason == progressDeadlineExceededReason
+		}
+	}
+	return false
+}
+
+// RolloutStatus returns the rollout status of the DaemonSet, interpreting its updated and available scheduled Pod counts.
+// The State is unknown for DaemonSets not using the RollingUpdate strategy.
+func (r *DaemonSet) RolloutStatus() *RolloutStatus {
+	if r.DaemonSet == nil {
+		return &RolloutStatus{}
+	}
+	s := RolloutStatus{
+		Kind:      DaemonSetKind,
+		Namespace: r.Namespace,
+		Name:      r.Name,
+		Desired:   r.Status.DesiredNumberScheduled,
+		Current:   r.Status.CurrentNumberScheduled,
+		Updated:   r.Status.UpdatedNumberScheduled,
+		Ready:     r.Status.NumberReady,
+		Available: r.Status.NumberAvailable,
+	}
+	switch {
+	case r.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType:
+		s.State, s.Message = RolloutUnknown, fmt.Sprintf("rollout status is only available for the %v strategy type", appsv1.RollingUpdateDaemonSetStrategyType)
+	case r.Generation > r.Status.ObservedGeneration:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for daemon set %q spec update to be observed", r.Name)
+	case s.Updated < s.Desired:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated", r.Name, s.Updated, s.Desired)
+	case s.Available < s.Desired:
+		s.State, s.Message = RolloutProgressing, fmt.Sprintf("waiting for daemon set %q rollout to finish: %d of %d updated pods are available", r.Name, s.Available, s.Desired)
+	default:
+		s.State, s.Message = RolloutComplete, fmt.Sprintf("daemon set %q successfully rolled out", r.Name)
+	}
+	return &s
+}
+
+// RolloutStatus returns the rollout status of each Deployment in the collection.
+func (c *DeployomentCollection) RolloutStatus() []RolloutStatus {
+	var status []RolloutStatus
+	for i := 0; i < len(c.Items); i++ {
+		dep := Deployment{DeploymentAPIVersion, DeploymentKind, &c.Items[i]}
+		status = append(status, *dep.RolloutStatus())
+	}
+	return status
+}
+
+// RolloutStatus returns the rollout status of each DaemonSet in the collection.
+func (c *DaemonSetCollection) RolloutStatus() []RolloutStatus {
+	var status []RolloutStatus
+	for i := 0; i < len(c.Items); i++ {
+		ds := DaemonSet{DaemonSetAPIVersion, DaemonSetKind, &c.Items[i]}
+		status = append(status, *ds.RolloutStatus())
+	}
+	return status
+}
+
+// RolloutHistory returns the revisions of the Deployment from its owned ReplicaSets, oldest first.
+func (r *Deployment) RolloutHistory(c *Client) ([]RolloutRevision, error) {
+	if r.Deployment == nil {
+		return nil, fmt.Errorf("no deployment specified")
+	}
+	rsList, err := deploymentReplicaSets(c, r.Deployment)
+	if err != nil {
+		return nil, err
+	}
+	var history []RolloutRevision
+	for i := range rsList {
+		rs := &rsList[i]
+		history = append(history, RolloutRevision{
+			Revision:    replicaSetRevision(rs),
+			Source:      rs.Name,
+			ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Created:     rs.CreationTimestamp.Time,
+			Images:      podTemplateImages(&rs.Spec.Template),
+		})
+	}
+	return revisionChanges(history), nil
+}
+
+// RolloutHistory returns the revisions of the DaemonSet from its owned ControllerRevisions, oldest first.
+func (r *DaemonSet) RolloutHistory(c *Client) ([]RolloutRevision, error) {
+	if r.DaemonSet == nil {
+		return nil, fmt.Errorf("no daemonset specified")
+	}
+	list, err := c.CS.AppsV1().ControllerRevisions(r.Namespace).List(c.Options[ListOption].(*ListAction).Get())
+	if err != nil {
+		return nil, err
+	}
+	var history []RolloutRevision
+	for _, cr := range list.Items {
+		if ref := metav1.GetControllerOf(&cr); ref == nil || ref.UID != r.UID {
+			continue
+		}
+		var data struct {
+			Spec struct {
+				Template v1.PodTemplateSpec `json:"template"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal(cr.Data.Raw, &data); err != nil {
+			return nil, fmt.Errorf("unable to decode controller revision %v: %v", cr.Name, err)
+		}
+		history = append(history, RolloutRevision{
+			Revision:    cr.Revision,
+			Source:      cr.Name,
+			ChangeCause: cr.Annotations[changeCauseAnnotation],
+			Created:     cr.CreationTimestamp.Time,
+			Images:      podTemplateImages(&data.Spec.Template),
+		})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Revision < history[j].Revision
+	})
+	return revisionChanges(history), nil
+}
+
+// revisionChanges sets the image changes from the previous revision and marks the latest revision as current.
+func revisionChanges(history []RolloutRevision) []RolloutRevision {
+	for i := range history {
+		if i > 0 {
+			history[i].Changes = imageChanges(history[i-1].Images, history[i].Images)
+		}
+	}
+	if len(history) > 0 {
+		history[len(history)-1].Current = true
+	}
+	return history
+}
+
+// imageChanges returns the container image changes between two revisions, sorted by container name.
+func imageChanges(from, to map[string]string) []ImageChange {
+	var changes []ImageChange
+	for name, image := range to {
+		if from[name] != image {
+			changes = append(changes, ImageChange{Container: name, From: from[name], To: image})
+		}
+	}
+	for name, image := range from {
+		if _, ok := to[name]; !ok {
+			changes = append(changes, ImageChange{Container: name, From: image})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Container < changes[j].Container
+	})
+	return changes
+}
+
+// podTemplateImages returns the container images of the Pod template keyed by container name.
+func podTemplateImages(template *v1.PodTemplateSpec) map[string]string {
+	images := make(map[string]string)
+	for _, container := range template.Spec.InitContainers {
+		images[container.Name] = container.Image
+	}
+	for _, container := range template.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+	return images
+}
@@ -0,0 +1,281 @@
+package ak8s
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/api/core/v1"
+)
+
+// Secret Redaction Constants:
+const (
+	SecretRedacted = `REDACTED`
+)
+
+// RegistryCredential contains the credentials for a single registry found in a docker config secret.
+type RegistryCredential struct {
+	Registry string `json:"registry"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// ServiceAccountToken contains the decoded values of a service account token secret.
+// Claims are decoded from the token payload but are not verified.
+type ServiceAccountToken struct {
+	ServiceAccount string                 `json:"serviceAccount"`
+	Namespace      string                 `json:"namespace"`
+	Token          string                 `json:"token"`
+	CACert         []byte                 `json:"caCert,omitempty"`
+	Claims         map[string]interface{} `json:"claims,omitempty"`
+}
+
+// Keys returns the sorted data keys of the Secret.
+func (r *Secret) Keys() []string {
+	if r.Secret == nil {
+		return nil
+	}
+	keys := make(map[string]bool)
+	for k := range r.Data {
+		keys[k] = true
+	}
+	for k := range r.StringData {
+		keys[k] = true
+	}
+	var list []string
+	for k := range keys {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Value returns the decoded value for the given key.
+func (r *Secret) Value(key string) ([]byte, error) {
+	if r.Secret == nil {
+		return nil, fmt.Errorf("no secret specified")
+	}
+	if v, ok := r.StringData[key]; ok {
+		return []byte(v), nil
+	}
+	if v, ok := r.Data[key]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %v not found in secret %v", key, r.Name)
+}
+
+// StringValue returns the decoded value for the given key as a string.
+func (r *Secret) StringValue(key string) (string, error) {
+	v, err := r.Value(key)
+	return string(v), err
+}
+
+// DecodedData returns all decoded values of the Secret as strings.
+func (r *Secret) DecodedData() map[string]string {
+	data := make(map[string]string)
+	if r.Secret == nil {
+		return data
+	}
+	for k, v := range r.Data {
+		data[k] = string(v)
+	}
+	for k, v := range r.StringData {
+		data[k] = v
+	}
+	return data
+}
+
+// TLSCertificates returns the parsed certificate chain from a kubernetes.io/tls Secret, leaf first.
+func (r *Secret) TLSCertificates() ([]*x509.Certificate, error) {
+	data, err := r.Value(v1.TLSCertKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseCertificates(data)
+}
+
+// TLSPrivateKey returns the parsed private key from a kubernetes.io/tls Secret.
+func (r *Secret) TLSPrivateKey() (crypto.PrivateKey, error) {
+	data, err := r.Value(v1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found for %v in secret %v", v1.TLSPrivateKeyKey, r.Name)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unable to parse private key in secret %v", r.Name)
+}
+
+// TLSExpiry returns the expiration time of the leaf certificate from a kubernetes.io/tls Secret.
+func (r *Secret) TLSExpiry() (time.Time, error) {
+	certs, err := r.TLSCertificates()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return certs[0].NotAfter, nil
+}
+
+// RegistryCredentials returns the registry credentials from a kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg Secret.
+func (r *Secret) RegistryCredentials() ([]RegistryCredential, error) {
+	if r.Secret == nil {
+		return nil, fmt.Errorf("no secret specified")
+	}
+	auths := make(map[string]RegistryCredential)
+	switch r.Type {
+	case v1.SecretTypeDockerConfigJson:
+		data, err := r.Value(v1.DockerConfigJsonKey)
+		if err != nil {
+			return nil, err
+		}
+		var config struct {
+			Auths map[string]RegistryCredential `json:"auths"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+		auths = config.Auths
+	case v1.SecretTypeDockercfg:
+		data, err := r.Value(v1.DockerConfigKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &auths); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("secret %v is not a docker config secret: %v", r.Name, r.Type)
+	}
+	var creds []RegistryCredential
+	for registry, cred := range auths {
+		cred.Registry = registry
+		if cred.Username == "" && cred.Auth != "" {
+			if decoded, err := base64.StdEncoding.DecodeString(cred.Auth); err == nil {
+				parts := strings.SplitN(string(decoded), ":", 2)
+				if len(parts) == 2 {
+					cred.Username, cred.Password = parts[0], parts[1]
+				}
+			}
+		}
+		creds = append(creds, cred)
+	}
+	sort.Slice(creds, func(i, j int) bool {
+		return creds[i].Registry < creds[j].Registry
+	})
+	return creds, nil
+}
+
+// ServiceAccountToken returns the decoded values from a kubernetes.io/service-account-token Secret.
+func (r *Secret) ServiceAccountToken() (*ServiceAccountToken, error) {
+	if r.Secret == nil {
+		return nil, fmt.Errorf("no secret specified")
+	}
+	if r.Type != v1.SecretTypeServiceAccountToken {
+		return nil, fmt.Errorf("secret %v is not a service account token secret: %v", r.Name, r.Type)
+	}
+	token, err := r.StringValue(v1.ServiceAccountTokenKey)
+	if err != nil {
+		return nil, err
+	}
+	sat := ServiceAccountToken{
+		ServiceAccount: r.Annotations[v1.ServiceAccountNameKey],
+		Namespace:      r.Namespace,
+		Token:          token,
+		CACert:         r.Data[v1.ServiceAccountRootCAKey],
+	}
+	if ns, ok := r.Data[v1.ServiceAccountNamespaceKey]; ok {
+		sat.Namespace = string(ns)
+	}
+	if parts := strings.Split(token, "."); len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "=")); err == nil {
+			json.Unmarshal(payload, &sat.Claims)
+		}
+	}
+	return &sat, nil
+}
+
+// Redacted returns a copy of the Secret with all values replaced, keeping the keys, eg. for display.
+func (r *Secret) Redacted() *Secret {
+	if r.Secret == nil {
+		return &Secret{}
+	}
+	return &Secret{
+		r.APIVersion,
+		r.Kind,
+		redactSecret(r.Secret),
+	}
+}
+
+// Redacted returns a copy of the collection with all Secret values replaced, keeping the keys, eg. for display.
+func (c *SecretCollection) Redacted() *SecretCollection {
+	if c.SecretList == nil {
+		return &SecretCollection{}
+	}
+	list := c.SecretList.DeepCopy()
+	for i := range list.Items {
+		list.Items[i] = *redactSecret(&list.Items[i])
+	}
+	return &SecretCollection{
+		c.APIVersion,
+		c.Kind,
+		list,
+	}
+}
+
+// redactSecret returns a copy of secret with its values replaced by SecretRedacted.
+func redactSecret(secret *v1.Secret) *v1.Secret {
+	s := secret.DeepCopy()
+	if len(s.Data) > 0 || len(s.StringData) > 0 {
+		data := make(map[string]string)
+		for k := range s.Data {
+			data[k] = SecretRedacted
+		}
+		for k := range s.StringData {
+			data[k] = SecretRedacted
+		}
+		s.Data = nil
+		s.StringData = data
+	}
+	return s
+}
+
+// parseCertificates returns all certificates found in PEM encoded data.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) < 1 {
+		return nil, fmt.Errorf("no certificates found")
+	}
+	return certs, nil
+}
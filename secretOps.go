@@ -0,0 +1,209 @@
+package ak8s
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+// NewOpaqueSecret returns a new Opaque v1.Secret containing the given data.
+func NewOpaqueSecret(name string, data map[string][]byte) *v1.Secret {
+	return newSecret(name, v1.SecretTypeOpaque, data)
+}
+
+// NewTLSSecret returns a new kubernetes.io/tls v1.Secret from PEM encoded certificate and key data.
+// The certificate and key are validated as a matching pair.
+func NewTLSSecret(name string, certPEM, keyPEM []byte) (*v1.Secret, error) {
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, err
+	}
+	return newSecret(name, v1.SecretTypeTLS, map[string][]byte{
+		v1.TLSCertKey:       certPEM,
+		v1.TLSPrivateKeyKey: keyPEM,
+	}), nil
+}
+
+// NewTLSSecretFromFiles returns a new kubernetes.io/tls v1.Secret from PEM encoded certificate and key files.
+func NewTLSSecretFromFiles(name, certFile, keyFile string) (*v1.Secret, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewTLSSecret(name, certPEM, keyPEM)
+}
+
+// NewDockerRegistrySecret returns a new kubernetes.io/dockerconfigjson v1.Secret for the given registry server and credentials.
+func NewDockerRegistrySecret(name, server, username, password, email string) (*v1.Secret, error) {
+	if server == "" {
+		return nil, fmt.Errorf("no registry server specified")
+	}
+	config := map[string]map[string]RegistryCredential{
+		`auths`: {
+			server: {
+				Username: username,
+				Password: password,
+				Email:    email,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + `:` + password)),
+			},
+		},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return newSecret(name, v1.SecretTypeDockerConfigJson, map[string][]byte{
+		v1.DockerConfigJsonKey: data,
+	}), nil
+}
+
+// NewBasicAuthSecret returns a new kubernetes.io/basic-auth v1.Secret for the given credentials.
+func NewBasicAuthSecret(name, username, password string) *v1.Secret {
+	return newSecret(name, v1.SecretTypeBasicAuth, map[string][]byte{
+		v1.BasicAuthUsernameKey: []byte(username),
+		v1.BasicAuthPasswordKey: []byte(password),
+	})
+}
+
+func newSecret(name string, secretType v1.SecretType, data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SecretAPIVersion,
+			Kind:       SecretKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Type: secretType,
+		Data: data,
+	}
+}
+
+// CreateSecret creates the given secret.
+// If the secret namespace is not set, the namespace set on the client is used or "default" if not set.
+func (c *Client) CreateSecret(secret *v1.Secret) (*Secret, error) {
+	s := &v1.Secret{}
+	if err := c.createObject(c.CS.CoreV1().RESTClient(), `secrets`, c.secretNamespace(secret), secret, s); err != nil {
+		return &Secret{}, err
+	}
+	return wrapSecret(s), nil
+}
+
+// UpdateSecret updates the given secret.
+// If the secret namespace is not set, the namespace set on the client is used or "default" if not set.
+func (c *Client) UpdateSecret(secret *v1.Secret) (*Secret, error) {
+	s := &v1.Secret{}
+	if err := c.updateObject(c.CS.CoreV1().RESTClient(), `secrets`, c.secretNamespace(secret), secret.Name, secret, s); err != nil {
+		return &Secret{}, err
+	}
+	return wrapSecret(s), nil
+}
+
+// RotateSecret replaces all data of the given secret name and optionally restarts the Deployments referencing it.
+// The names of any restarted Deployments are returned.
+// If the namespace is not set on the client, the "default" namespace is used.
+func (c *Client) RotateSecret(name string, data map[string][]byte, restart bool) (*Secret, []string, error) {
+	secret, err := c.GetSecret(name)
+	if err != nil {
+		return secret, nil, err
+	}
+	restarted, err := secret.Rotate(c, data, restart)
+	return secret, restarted, err
+}
+
+// Rotate atomically replaces all data of the Secret, retrying on update conflicts,
+// and optionally restarts the Deployments in its namespace that mount or reference it.
+// The names of any restarted Deployments are returned.
+func (r *Secret) Rotate(c *Client, data map[string][]byte, restart bool) ([]string, error) {
+	if r.Secret == nil {
+		return nil, fmt.Errorf("no secret specified")
+	}
+	secrets := c.CS.CoreV1().Secrets(r.Namespace)
+	current := r.Secret
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		update := current.DeepCopy()
+		update.Data = data
+		update.StringData = nil
+		s := &v1.Secret{}
+		err := c.updateObject(c.CS.CoreV1().RESTClient(), `secrets`, r.Namespace, r.Name, update, s)
+		if err == nil {
+			current = s
+			return nil
+		}
+		if latest, getErr := secrets.Get(r.Name, c.Options[GetOption].(*GetAction).Get()); getErr == nil {
+			current = latest
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Secret = wrapSecret(current).Secret
+	if !restart {
+		return nil, nil
+	}
+	deployments, err := c.CS.AppsV1().Deployments(r.Namespace).List(c.Options[ListOption].(*ListAction).Get())
+	if err != nil {
+		return nil, err
+	}
+	var restarted []string
+	var errd string
+	for _, dep := range deployments.Items {
+		if !podSpecReferencesSecret(&dep.Spec.Template.Spec, r.Name) {
+			continue
+		}
+		if err := rolloutRestartDeployment(c, &dep); err != nil {
+			errd += (fmt.Sprintf("%v: %v", dep.Name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		restarted = append(restarted, dep.Name)
+	}
+	if errd != "" {
+		return restarted, fmt.Errorf("%v", errd)
+	}
+	return restarted, nil
+}
+
+// secretNamespace returns the namespace of the secret, or the namespace set on the client or "default" if not set.
+func (c *Client) secretNamespace(secret *v1.Secret) string {
+	ns := secret.Namespace
+	if ns == "" {
+		ns = c.NS
+		if ns == "" {
+			ns = `default`
+		}
+	}
+	return ns
+}
+
+func wrapSecret(s *v1.Secret) *Secret {
+	s.SetGroupVersionKind(schema.GroupVersionKind{
+		Version: SecretAPIVersion,
+		Kind:    SecretKind,
+	})
+	return &Secret{
+		s.APIVersion,
+		s.Kind,
+		s,
+	}
+}
+
+// podSpecReferencesSecret returns true if the PodSpec mounts, projects or references the named Secret from env.
+func podSpecReferencesSecret(spec *v1.PodSpec, name string) bool {
+	for _, via := range podSpecReferences(spec).secrets[name] {
+		if via != RefViaImagePullSecrets {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,351 @@
+package ak8s
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// EndpointSlice Constants:
+const (
+	endpointSliceGroup        = `discovery.k8s.io`
+	endpointSliceResource     = `endpointslices`
+	endpointSliceServiceLabel = `kubernetes.io/service-name`
+)
+
+// Service Health Constants:
+const (
+	ServiceIssueNoPods           = `selector matches no pods`
+	ServiceIssueNoReadyEndpoints = `no ready endpoints`
+)
+
+// ServiceEndpoint contains a single resolved endpoint address backing a Service.
+type ServiceEndpoint struct {
+	Address  string                `json:"address"`
+	Hostname string                `json:"hostname,omitempty"`
+	NodeName string                `json:"nodeName,omitempty"`
+	Pod      string                `json:"pod,omitempty"`
+	Ready    bool                  `json:"ready"`
+	Ports    []ServiceEndpointPort `json:"ports,omitempty"`
+}
+
+// ServiceEndpointPort contains a port exposed by a ServiceEndpoint.
+type ServiceEndpointPort struct {
+	Name     string      `json:"name,omitempty"`
+	Port     int32       `json:"port"`
+	Protocol v1.Protocol `json:"protocol,omitempty"`
+}
+
+// ServiceHealth summarizes what backs a Service.
+// Issues lists any problems found, eg. a selector matching no pods or no ready endpoints.
+type ServiceHealth struct {
+	Namespace         string            `json:"namespace"`
+	Name              string            `json:"name"`
+	Type              v1.ServiceType    `json:"type"`
+	Selector          map[string]string `json:"selector,omitempty"`
+	MatchingPods      int               `json:"matchingPods"`
+	ReadyEndpoints    int               `json:"readyEndpoints"`
+	NotReadyEndpoints int               `json:"notReadyEndpoints"`
+	Issues            []string          `json:"issues,omitempty"`
+}
+
+// Healthy returns true if no issues were found for the Service.
+func (h *ServiceHealth) Healthy() bool {
+	return len(h.Issues) < 1
+}
+
+// Pods returns the Pods in the Service namespace matching the Service selector.
+// An empty collection is returned for Services without a selector.
+func (r *Service) Pods(c *Client) (*PodCollection, error) {
+	if r.Service == nil {
+		return &PodCollection{}, fmt.Errorf("no service specified")
+	}
+	list := &v1.PodList{}
+	if len(r.Spec.Selector) > 0 {
+		opts := c.Options[ListOption].(*ListAction).Get()
+		opts.LabelSelector = labels.SelectorFromSet(r.Spec.Selector).String()
+		l, err := c.CS.CoreV1().Pods(r.Namespace).List(opts)
+		if err != nil {
+			return &PodCollection{}, err
+		}
+		list = l
+	}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Version: PodAPIVersion,
+		Kind:    PodListKind,
+	})
+	for i := 0; i < len(list.Items); i++ {
+		list.Items[i].SetGroupVersionKind(schema.GroupVersionKind{
+			Version: PodAPIVersion,
+			Kind:    PodKind,
+		})
+	}
+	return &PodCollection{
+		list.APIVersion,
+		list.Kind,
+		list,
+	}, nil
+}
+
+// Endpoints returns the endpoints backing the Service, read from its Endpoints resource
+// or from its EndpointSlices if the Endpoints resource is not found.
+func (r *Service) Endpoints(c *Client) ([]ServiceEndpoint, error) {
+	if r.Service == nil {
+		return nil, fmt.Errorf("no service specified")
+	}
+	ep, err := c.CS.CoreV1().Endpoints(r.Namespace).Get(r.Name, c.Options[GetOption].(*GetAction).Get())
+	switch {
+	case err == nil:
+		return endpointsFromV1(ep), nil
+	case errors.IsNotFound(err):
+		return r.endpointSlices(c)
+	default:
+		return nil, err
+	}
+}
+
+// endpointSlices reads the EndpointSlices for the Service using the dynamic client.
+func (r *Service) endpointSlices(c *Client) ([]ServiceEndpoint, error) {
+	version, err := endpointSliceVersion(c)
+	if err != nil {
+		return nil, err
+	}
+	return r.endpointSlicesVersion(c, version)
+}
+
+// endpointSliceVersion returns the EndpointSlice API version served, or an empty string if not served.
+func endpointSliceVersion(c *Client) (string, error) {
+	for _, v := range []string{`v1`, `v1beta1`} {
+		ok, err := c.serverHasResource(endpointSliceGroup+`/`+v, endpointSliceResource)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return v, nil
+		}
+	}
+	return "", nil
+}
+
+// endpointSlicesVersion reads the EndpointSlices for the Service served by the given API version.
+func (r *Service) endpointSlicesVersion(c *Client, version string) ([]ServiceEndpoint, error) {
+	if version == "" {
+		return nil, nil
+	}
+	dc, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	gvr := schema.GroupVersionResource{Group: endpointSliceGroup, Version: version, Resource: endpointSliceResource}
+	list, err := dc.Resource(gvr).Namespace(r.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{endpointSliceServiceLabel: r.Name}).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []ServiceEndpoint
+	for _, item := range list.Items {
+		endpoints = append(endpoints, endpointsFromSlice(item.Object)...)
+	}
+	return endpoints, nil
+}
+
+// Health returns a ServiceHealth summary for the Service.
+func (r *Service) Health(c *Client) (*ServiceHealth, error) {
+	pods, err := r.Pods(c)
+	if err != nil {
+		return &ServiceHealth{}, err
+	}
+	endpoints, err := r.Endpoints(c)
+	if err != nil {
+		return &ServiceHealth{}, err
+	}
+	return r.health(pods.Len(), endpoints), nil
+}
+
+func (r *Service) health(matchingPods int, endpoints []ServiceEndpoint) *ServiceHealth {
+	h := ServiceHealth{
+		Namespace:    r.Namespace,
+		Name:         r.Name,
+		Type:         r.Spec.Type,
+		Selector:     r.Spec.Selector,
+		MatchingPods: matchingPods,
+	}
+	for _, ep := range endpoints {
+		if ep.Ready {
+			h.ReadyEndpoints++
+		} else {
+			h.NotReadyEndpoints++
+		}
+	}
+	if r.Spec.Type == v1.ServiceTypeExternalName {
+		return &h
+	}
+	if len(r.Spec.Selector) > 0 && matchingPods < 1 {
+		h.Issues = append(h.Issues, ServiceIssueNoPods)
+	}
+	if h.ReadyEndpoints < 1 {
+		h.Issues = append(h.Issues, ServiceIssueNoReadyEndpoints)
+	}
+	return &h
+}
+
+// Health returns a ServiceHealth summary for each Service in the collection.
+// Pods and Endpoints are listed once per namespace rather than per Service.
+func (c *ServiceCollection) Health(client *Client) ([]ServiceHealth, error) {
+	pods := make(map[string][]v1.Pod)
+	endpoints := make(map[string]map[string]*v1.Endpoints)
+	var sliceVersion *string
+	var health []ServiceHealth
+	for i := 0; i < len(c.Items); i++ {
+		svc := Service{ServiceAPIVersion, ServiceKind, &c.Items[i]}
+		ns := svc.Namespace
+		if _, ok := pods[ns]; !ok {
+			pl, err := client.CS.CoreV1().Pods(ns).List(client.Options[ListOption].(*ListAction).Get())
+			if err != nil {
+				return health, err
+			}
+			pods[ns] = pl.Items
+			el, err := client.CS.CoreV1().Endpoints(ns).List(client.Options[ListOption].(*ListAction).Get())
+			if err != nil {
+				return health, err
+			}
+			endpoints[ns] = make(map[string]*v1.Endpoints)
+			for j := range el.Items {
+				endpoints[ns][el.Items[j].Name] = &el.Items[j]
+			}
+		}
+		var matching int
+		if len(svc.Spec.Selector) > 0 {
+			selector := labels.SelectorFromSet(svc.Spec.Selector)
+			for _, pod := range pods[ns] {
+				if selector.Matches(labels.Set(pod.Labels)) {
+					matching++
+				}
+			}
+		}
+		var eps []ServiceEndpoint
+		if ep, ok := endpoints[ns][svc.Name]; ok {
+			eps = endpointsFromV1(ep)
+		} else {
+			if sliceVersion == nil {
+				version, err := endpointSliceVersion(client)
+				if err != nil {
+					return health, err
+				}
+				sliceVersion = &version
+			}
+			slices, err := svc.endpointSlicesVersion(client, *sliceVersion)
+			if err != nil {
+				return health, err
+			}
+			eps = slices
+		}
+		health = append(health, *svc.health(matching, eps))
+	}
+	return health, nil
+}
+
+// Unhealthy returns the Services in the collection with zero ready endpoints or selectors matching no pods.
+func (c *ServiceCollection) Unhealthy(client *Client) (*ServiceCollection, error) {
+	health, err := c.Health(client)
+	if err != nil {
+		return &ServiceCollection{}, err
+	}
+	var list v1.ServiceList
+	for i, h := range health {
+		if !h.Healthy() {
+			list.Items = append(list.Items, c.Items[i])
+		}
+	}
+	return &ServiceCollection{
+		c.APIVersion,
+		c.Kind,
+		&list,
+	}, nil
+}
+
+func endpointsFromV1(ep *v1.Endpoints) []ServiceEndpoint {
+	var endpoints []ServiceEndpoint
+	for _, subset := range ep.Subsets {
+		var ports []ServiceEndpointPort
+		for _, p := range subset.Ports {
+			ports = append(ports, ServiceEndpointPort{p.Name, p.Port, p.Protocol})
+		}
+		add := func(addr v1.EndpointAddress, ready bool) {
+			e := ServiceEndpoint{
+				Address:  addr.IP,
+				Hostname: addr.Hostname,
+				Ready:    ready,
+				Ports:    ports,
+			}
+			if addr.NodeName != nil {
+				e.NodeName = *addr.NodeName
+			}
+			if addr.TargetRef != nil && addr.TargetRef.Kind == PodKind {
+				e.Pod = addr.TargetRef.Name
+			}
+			endpoints = append(endpoints, e)
+		}
+		for _, addr := range subset.Addresses {
+			add(addr, true)
+		}
+		for _, addr := range subset.NotReadyAddresses {
+			add(addr, false)
+		}
+	}
+	return endpoints
+}
+
+func endpointsFromSlice(obj map[string]interface{}) []ServiceEndpoint {
+	var ports []ServiceEndpointPort
+	portList, _, _ := unstructured.NestedSlice(obj, "ports")
+	for _, p := range portList {
+		pm, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(pm, "name")
+		port, _, _ := unstructured.NestedInt64(pm, "port")
+		protocol, _, _ := unstructured.NestedString(pm, "protocol")
+		ports = append(ports, ServiceEndpointPort{name, int32(port), v1.Protocol(protocol)})
+	}
+	var endpoints []ServiceEndpoint
+	epList, _, _ := unstructured.NestedSlice(obj, "endpoints")
+	for _, e := range epList {
+		em, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ready, found, _ := unstructured.NestedBool(em, "conditions", "ready")
+		if !found {
+			ready = true
+		}
+		hostname, _, _ := unstructured.NestedString(em, "hostname")
+		nodeName, found, _ := unstructured.NestedString(em, "nodeName")
+		if !found {
+			nodeName, _, _ = unstructured.NestedString(em, "topology", "kubernetes.io/hostname")
+		}
+		var pod string
+		if kind, _, _ := unstructured.NestedString(em, "targetRef", "kind"); kind == PodKind {
+			pod, _, _ = unstructured.NestedString(em, "targetRef", "name")
+		}
+		addrs, _, _ := unstructured.NestedStringSlice(em, "addresses")
+		for _, addr := range addrs {
+			endpoints = append(endpoints, ServiceEndpoint{
+				Address:  addr,
+				Hostname: hostname,
+				NodeName: nodeName,
+				Pod:      pod,
+				Ready:    ready,
+				Ports:    ports,
+			})
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,226 @@
+package ak8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// AllContainers may be used as the container name given to SetImage to update the image of every container.
+const AllContainers = `*`
+
+// ImageUpdate reports the image changes made to a workload, or the changes that would be made if DryRun is set.
+type ImageUpdate struct {
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Changes   []ImageChange `json:"changes"`
+	DryRun    bool          `json:"dryRun"`
+}
+
+// SetImage updates the images of the Deployment containers, given as a map of container name to image.
+// The container name "*" updates every container. An error is returned if a named container is not found.
+// If dryRun is set, the changes are reported but the Deployment is not updated.
+// If dry-run is set on the client instead, the update is sent as a server-side dry run.
+func (r *Deployment) SetImage(c *Client, images map[string]string, dryRun bool) (*ImageUpdate, error) {
+	return r.setImage(c, images, dryRun, true)
+}
+
+func (r *Deployment) setImage(c *Client, images map[string]string, dryRun, strict bool) (*ImageUpdate, error) {
+	if r.Deployment == nil {
+		return &ImageUpdate{}, fmt.Errorf("no deployment specified")
+	}
+	return setPodSpecImages(DeploymentKind, r.Namespace, r.Name, &r.Spec.Template.Spec, images, dryRun || c.DryRun(), dryRun, strict, func(patch []byte) error {
+		return r.patch(c, types.StrategicMergePatchType, patch)
+	})
+}
+
+// SetImage updates the images of the DaemonSet containers, given as a map of container name to image.
+// The container name "*" updates every container. An error is returned if a named container is not found.
+// If dryRun is set, the changes are reported but the DaemonSet is not updated.
+// If dry-run is set on the client instead, the update is sent as a server-side dry run.
+func (r *DaemonSet) SetImage(c *Client, images map[string]string, dryRun bool) (*ImageUpdate, error) {
+	return r.setImage(c, images, dryRun, true)
+}
+
+func (r *DaemonSet) setImage(c *Client, images map[string]string, dryRun, strict bool) (*ImageUpdate, error) {
+	if r.DaemonSet == nil {
+		return &ImageUpdate{}, fmt.Errorf("no daemonset specified")
+	}
+	return setPodSpecImages(DaemonSetKind, r.Namespace, r.Name, &r.Spec.Template.Spec, images, dryRun || c.DryRun(), dryRun, strict, func(patch []byte) error {
+		ds := &appsv1.DaemonSet{}
+		if err := c.patchObject(c.CS.AppsV1().RESTClient(), `daemonsets`, r.Namespace, r.Name, types.StrategicMergePatchType, patch, ds); err != nil {
+			return err
+		}
+		ds.APIVersion, ds.Kind = r.APIVersion, r.Kind
+		r.DaemonSet = ds
+		return nil
+	})
+}
+
+// SetImage updates the images of the ReplicaSet containers, given as a map of container name to image.
+// The container name "*" updates every container. An error is returned if a named container is not found.
+// If dryRun is set, the changes are reported but the ReplicaSet is not updated.
+// If dry-run is set on the client instead, the update is sent as a server-side dry run.
+// Existing Pods are not replaced by the ReplicaSet, only Pods created after the update use the new images.
+func (r *ReplicaSet) SetImage(c *Client, images map[string]string, dryRun bool) (*ImageUpdate, error) {
+	return r.setImage(c, images, dryRun, true)
+}
+
+func (r *ReplicaSet) setImage(c *Client, images map[string]string, dryRun, strict bool) (*ImageUpdate, error) {
+	if r.ReplicaSet == nil {
+		return &ImageUpdate{}, fmt.Errorf("no replicaset specified")
+	}
+	return setPodSpecImages(ReplicaSetKind, r.Namespace, r.Name, &r.Spec.Template.Spec, images, dryRun || c.DryRun(), dryRun, strict, func(patch []byte) error {
+		rs := &appsv1.ReplicaSet{}
+		if err := c.patchObject(c.CS.AppsV1().RESTClient(), `replicasets`, r.Namespace, r.Name, types.StrategicMergePatchType, patch, rs); err != nil {
+			return err
+		}
+		rs.APIVersion, rs.Kind = r.APIVersion, r.Kind
+		r.ReplicaSet = rs
+		return nil
+	})
+}
+
+// SetImage updates the container images of all Deployments in the collection, eg. the result of a Search.
+// Deployments without any of the named containers are skipped. Only Deployments with changes are reported.
+func (c *DeployomentCollection) SetImage(client *Client, images map[string]string, dryRun bool) ([]ImageUpdate, error) {
+	var updates []ImageUpdate
+	var errd string
+	for i := 0; i < len(c.Items); i++ {
+		dep := Deployment{DeploymentAPIVersion, DeploymentKind, &c.Items[i]}
+		update, err := dep.setImage(client, images, dryRun, false)
+		if err != nil {
+			errd += (fmt.Sprintf("%v: %v", dep.Name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		if len(update.Changes) > 0 {
+			c.Items[i] = *dep.Deployment
+			updates = append(updates, *update)
+		}
+	}
+	if errd != "" {
+		return updates, fmt.Errorf("%v", errd)
+	}
+	return updates, nil
+}
+
+// SetImage updates the container images of all DaemonSets in the collection, eg. the result of a Search.
+// DaemonSets without any of the named containers are skipped. Only DaemonSets with changes are reported.
+func (c *DaemonSetCollection) SetImage(client *Client, images map[string]string, dryRun bool) ([]ImageUpdate, error) {
+	var updates []ImageUpdate
+	var errd string
+	for i := 0; i < len(c.Items); i++ {
+		ds := DaemonSet{DaemonSetAPIVersion, DaemonSetKind, &c.Items[i]}
+		update, err := ds.setImage(client, images, dryRun, false)
+		if err != nil {
+			errd += (fmt.Sprintf("%v: %v", ds.Name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		if len(update.Changes) > 0 {
+			c.Items[i] = *ds.DaemonSet
+			updates = append(updates, *update)
+		}
+	}
+	if errd != "" {
+		return updates, fmt.Errorf("%v", errd)
+	}
+	return updates, nil
+}
+
+// SetImage updates the container images of all ReplicaSets in the collection, eg. the result of a Search.
+// ReplicaSets without any of the named containers are skipped. Only ReplicaSets with changes are reported.
+func (c *ReplicaSetCollection) SetImage(client *Client, images map[string]string, dryRun bool) ([]ImageUpdate, error) {
+	var updates []ImageUpdate
+	var errd string
+	for i := 0; i < len(c.Items); i++ {
+		rs := ReplicaSet{ReplicaSetAPIVersion, ReplicaSetKind, &c.Items[i]}
+		update, err := rs.setImage(client, images, dryRun, false)
+		if err != nil {
+			errd += (fmt.Sprintf("%v: %v", rs.Name, err) + fmt.Sprintf("\n"))
+			continue
+		}
+		if len(update.Changes) > 0 {
+			c.Items[i] = *rs.ReplicaSet
+			updates = append(updates, *update)
+		}
+	}
+	if errd != "" {
+		return updates, fmt.Errorf("%v", errd)
+	}
+	return updates, nil
+}
+
+// setPodSpecImages computes the image changes to the PodSpec and, unless skipPatch is set, applies them using a strategic merge patch.
+// When strict is set, an error is returned for any named container not found in the PodSpec.
+func setPodSpecImages(kind, namespace, name string, spec *v1.PodSpec, images map[string]string, dryRun, skipPatch, strict bool, patch func([]byte) error) (*ImageUpdate, error) {
+	if len(images) < 1 {
+		return &ImageUpdate{}, fmt.Errorf("no images specified")
+	}
+	update := ImageUpdate{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		DryRun:    dryRun,
+	}
+	found := make(map[string]bool)
+	changed := func(containers []v1.Container) []map[string]string {
+		var patches []map[string]string
+		for _, container := range containers {
+			image, ok := images[container.Name]
+			if ok {
+				found[container.Name] = true
+			} else if image, ok = images[AllContainers]; !ok {
+				continue
+			}
+			if container.Image == image {
+				continue
+			}
+			update.Changes = append(update.Changes, ImageChange{Container: container.Name, From: container.Image, To: image})
+			patches = append(patches, map[string]string{`name`: container.Name, `image`: image})
+		}
+		return patches
+	}
+	initContainers := changed(spec.InitContainers)
+	containers := changed(spec.Containers)
+	if strict {
+		var missing []string
+		for container := range images {
+			if container != AllContainers && !found[container] {
+				missing = append(missing, container)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return &ImageUpdate{}, fmt.Errorf("unable to find containers %v in %v %v", missing, kind, name)
+		}
+	}
+	if skipPatch || len(update.Changes) < 1 {
+		return &update, nil
+	}
+	podSpec := make(map[string]interface{})
+	if len(initContainers) > 0 {
+		podSpec[`initContainers`] = initContainers
+	}
+	if len(containers) > 0 {
+		podSpec[`containers`] = containers
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		`spec`: map[string]interface{}{
+			`template`: map[string]interface{}{
+				`spec`: podSpec,
+			},
+		},
+	})
+	if err != nil {
+		return &ImageUpdate{}, err
+	}
+	if err := patch(data); err != nil {
+		return &ImageUpdate{}, err
+	}
+	return &update, nil
+}